@@ -47,6 +47,9 @@ func main() {
 
 	cont := container.New()
 
+	go cont.EventDispatcher.Run(ctx)
+	go cont.WebhookService.Run(ctx)
+
 	err = http.Server(
 		ctx,
 		http.CreateRouter(cont),
@@ -1,35 +1,143 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const EnvironmentDevelopment = "development"
+
 type Configuration struct {
-	DatabaseName        string
-	DatabaseHost        string
-	DatabasePort        string
-	DatabaseUser        string
-	DatabasePassword    string
-	MigrateToVersion    string
-	MigrationLocation   string
-	CloudinaryNameKey   string
-	CloudinaryApiKey    string
-	CloudinarySecretKey string
+	Environment             string
+	DatabaseName            string
+	DatabaseHost            string
+	DatabasePort            string
+	DatabaseUser            string
+	DatabasePassword        string
+	MigrateToVersion        string
+	MigrationLocation       string
+	CloudinaryNameKey       string
+	CloudinaryApiKey        string
+	CloudinarySecretKey     string
+	StorageDriver           string
+	S3Endpoint              string
+	S3Region                string
+	S3Bucket                string
+	S3AccessKey             string
+	S3SecretKey             string
+	S3PublicUrl             string
+	UploadMaxBytes          int32
+	ImageMaxDimension       int32
+	SmtpHost                string
+	SmtpPort                string
+	SmtpUser                string
+	SmtpPassword            string
+	MailFrom                string
+	FrontendUrl             string
+	JwtAlgorithm            string
+	JwtKeys                 string
+	AccessTokenTTL          time.Duration
+	RefreshTokenTTL         time.Duration
+	TotpIssuer              string
+	LoginMaxAccountAttempts int32
+	LoginMaxIpAttempts      int32
+	LoginLockoutDuration    time.Duration
+	ApiUrl                  string
+	TrustedProxies          []string
+	OidcProviders           []OidcProvider
+	PaymentProvider         string
+	PaymentWebhookSecret    string
+	TransferDailyLimit      int32
+}
+
+type OidcProvider struct {
+	Name         string
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	AuthUrl      string
+	TokenUrl     string
+	UserInfoUrl  string
+	EmailsUrl    string
+	SubjectClaim string
+	Scopes       []string
 }
 
 func GetConfiguration() Configuration {
 	return Configuration{
-		DatabaseName:        getOrDefault("DB_NAME", "restapi_dev"),
-		DatabaseHost:        getOrDefault("DB_HOST", "127.0.0.1"),
-		DatabasePort:        getOrDefault("DB_PORT", "5432"),
-		DatabaseUser:        getOrDefault("DB_USER", "postgres"),
-		DatabasePassword:    getOrDefault("DB_PASSWORD", "postgres"),
-		MigrateToVersion:    getOrDefault("MIGRATE", "latest"),
-		MigrationLocation:   getOrDefault("MIGRATION_LOCATION", "migrations"),
-		CloudinaryNameKey:   os.Getenv("CLOUDINARY_NAME_KEY"),
-		CloudinaryApiKey:    os.Getenv("CLOUDINARY_API_KEY"),
-		CloudinarySecretKey: os.Getenv("CLOUDINARY_SECRET_KEY"),
+		Environment:             getOrDefault("APP_ENV", "production"),
+		DatabaseName:            getOrDefault("DB_NAME", "restapi_dev"),
+		DatabaseHost:            getOrDefault("DB_HOST", "127.0.0.1"),
+		DatabasePort:            getOrDefault("DB_PORT", "5432"),
+		DatabaseUser:            getOrDefault("DB_USER", "postgres"),
+		DatabasePassword:        getOrDefault("DB_PASSWORD", "postgres"),
+		MigrateToVersion:        getOrDefault("MIGRATE", "latest"),
+		MigrationLocation:       getOrDefault("MIGRATION_LOCATION", "migrations"),
+		CloudinaryNameKey:       os.Getenv("CLOUDINARY_NAME_KEY"),
+		CloudinaryApiKey:        os.Getenv("CLOUDINARY_API_KEY"),
+		CloudinarySecretKey:     os.Getenv("CLOUDINARY_SECRET_KEY"),
+		StorageDriver:           getOrDefault("STORAGE_DRIVER", "cloudinary"),
+		S3Endpoint:              os.Getenv("S3_ENDPOINT"),
+		S3Region:                getOrDefault("S3_REGION", "us-east-1"),
+		S3Bucket:                os.Getenv("S3_BUCKET"),
+		S3AccessKey:             os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:             os.Getenv("S3_SECRET_KEY"),
+		S3PublicUrl:             os.Getenv("S3_PUBLIC_URL"),
+		UploadMaxBytes:          getInt32OrDefault("UPLOAD_MAX_BYTES", 10<<20),
+		ImageMaxDimension:       getInt32OrDefault("IMAGE_MAX_DIMENSION", 6000),
+		SmtpHost:                os.Getenv("SMTP_HOST"),
+		SmtpPort:                getOrDefault("SMTP_PORT", "587"),
+		SmtpUser:                os.Getenv("SMTP_USER"),
+		SmtpPassword:            os.Getenv("SMTP_PASSWORD"),
+		MailFrom:                getOrDefault("MAIL_FROM", "no-reply@party-app.local"),
+		FrontendUrl:             getOrDefault("FRONTEND_URL", "http://localhost:3000"),
+		JwtAlgorithm:            getOrDefault("JWT_ALGORITHM", "HS256"),
+		JwtKeys:                 os.Getenv("JWT_KEYS"),
+		AccessTokenTTL:          getDurationOrDefault("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:         getDurationOrDefault("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		TotpIssuer:              getOrDefault("TOTP_ISSUER", "Party App"),
+		LoginMaxAccountAttempts: getInt32OrDefault("LOGIN_MAX_ACCOUNT_ATTEMPTS", 10),
+		LoginMaxIpAttempts:      getInt32OrDefault("LOGIN_MAX_IP_ATTEMPTS", 50),
+		LoginLockoutDuration:    getDurationOrDefault("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		ApiUrl:                  getOrDefault("API_URL", "http://localhost:8080"),
+		TrustedProxies:          strings.FieldsFunc(os.Getenv("TRUSTED_PROXIES"), func(r rune) bool { return r == ',' || r == ' ' }),
+		OidcProviders:           getOidcProviders(),
+		PaymentProvider:         os.Getenv("PAYMENT_PROVIDER"),
+		PaymentWebhookSecret:    os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		TransferDailyLimit:      getInt32OrDefault("TRANSFER_DAILY_LIMIT", 1000),
+	}
+}
+
+func (c Configuration) IsDevelopment() bool {
+	return c.Environment == EnvironmentDevelopment
+}
+
+func getOidcProviders() []OidcProvider {
+	var providers []OidcProvider
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OidcProvider{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientId:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			AuthUrl:      os.Getenv(prefix + "AUTH_URL"),
+			TokenUrl:     os.Getenv(prefix + "TOKEN_URL"),
+			UserInfoUrl:  os.Getenv(prefix + "USERINFO_URL"),
+			EmailsUrl:    os.Getenv(prefix + "EMAILS_URL"),
+			SubjectClaim: getOrDefault(prefix+"SUBJECT_CLAIM", "sub"),
+			Scopes:       strings.Fields(getOrDefault(prefix+"SCOPES", "openid email profile")),
+		})
 	}
+	return providers
 }
 
 func getOrDefault(key, defaultVal string) string {
@@ -39,3 +147,29 @@ func getOrDefault(key, defaultVal string) string {
 	}
 	return env
 }
+
+func getDurationOrDefault(key string, defaultVal time.Duration) time.Duration {
+	env, set := os.LookupEnv(key)
+	if !set || env == "" {
+		return defaultVal
+	}
+	duration, err := time.ParseDuration(env)
+	if err != nil {
+		log.Printf("Config: invalid duration in %s, using %s", key, defaultVal)
+		return defaultVal
+	}
+	return duration
+}
+
+func getInt32OrDefault(key string, defaultVal int32) int32 {
+	env, set := os.LookupEnv(key)
+	if !set || env == "" {
+		return defaultVal
+	}
+	value, err := strconv.ParseInt(env, 10, 32)
+	if err != nil {
+		log.Printf("Config: invalid number in %s, using %d", key, defaultVal)
+		return defaultVal
+	}
+	return int32(value)
+}
@@ -3,14 +3,18 @@ package container
 import (
 	"go-rest-api/config"
 	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
 	"go-rest-api/internal/infra/database"
 	"go-rest-api/internal/infra/database/repositories"
 	"go-rest-api/internal/infra/filesystem"
 	"go-rest-api/internal/infra/http/controllers"
 	"go-rest-api/internal/infra/http/middlewares"
+	"go-rest-api/internal/infra/mail"
+	"go-rest-api/internal/infra/oidc"
+	"go-rest-api/internal/infra/payments"
+	"go-rest-api/internal/infra/tokens"
+	"log"
 	"net/http"
-
-	"github.com/go-chi/jwtauth/v5"
 )
 
 type Container struct {
@@ -22,68 +26,197 @@ type Container struct {
 type Services struct {
 	app.UserService
 	app.SessionService
+	app.TwoFactorService
+	app.OidcService
+	app.AdminService
+	app.PersonalAccessTokenService
+	app.TopUpService
+	app.TransferService
+	app.PromoCodeService
+	app.TicketTierService
 	app.PartyService
+	app.PartySeriesService
+	app.PartyImageService
+	app.UploadService
+	app.CalendarService
 	app.MemberService
 	app.LikeService
+	app.WebhookService
+	app.EventDispatcher
 }
 
 type Controllers struct {
 	controllers.UserController
 	controllers.SessionController
+	controllers.TwoFactorController
+	controllers.OidcController
+	controllers.AdminController
+	controllers.PersonalAccessTokenController
+	controllers.TopUpController
+	controllers.TransferController
+	controllers.PromoCodeController
+	controllers.TicketTierController
 	controllers.PartyController
+	controllers.PartySeriesController
+	controllers.PartyImageController
+	controllers.UploadController
+	controllers.CalendarController
+	controllers.FeedController
 	controllers.MemberController
 	controllers.LikeController
+	controllers.WebhookController
+	controllers.JwksController
 }
 
 type Middleware struct {
-	AuthMw func(http.Handler) http.Handler
+	AuthMw   func(http.Handler) http.Handler
+	RealIpMw func(http.Handler) http.Handler
 }
 
 func New() Container {
-	tknAuth := jwtauth.New("HS256", []byte("1234567890"), nil)
 	db := database.New()
 	cfg := config.GetConfiguration()
 
+	tknAuth, err := tokens.NewJWTAuth(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure JWT keys: %s", err)
+	}
+
 	userRepo := repositories.NewUserRepository(db)
 	sessionRepo := repositories.NewSessionRepository(db)
 	partyRepo := repositories.NewPartyRepository(db)
 	memberRepo := repositories.NewMemberRepository(db)
 	likeRepo := repositories.NewLikeRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepository(db)
+	webhookJobRepo := repositories.NewWebhookJobRepository(db)
+	outboxRepo := repositories.NewOutboxRepository(db)
+	userTokenRepo := repositories.NewUserTokenRepository(db)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
+	recoveryCodeRepo := repositories.NewRecoveryCodeRepository(db)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(db)
+	userIdentityRepo := repositories.NewUserIdentityRepository(db)
+	oidcStateRepo := repositories.NewOidcStateRepository(db)
+	pointTransactionRepo := repositories.NewPointTransactionRepository(db)
+	personalAccessTokenRepo := repositories.NewPersonalAccessTokenRepository(db)
+	topUpRepo := repositories.NewTopUpRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	promoCodeRepo := repositories.NewPromoCodeRepository(db)
+	ticketTierRepo := repositories.NewTicketTierRepository(db)
+	calendarFeedRepo := repositories.NewCalendarFeedRepository(db)
+	partyImageRepo := repositories.NewPartyImageRepository(db)
+	uploadRepo := repositories.NewUploadRepository(db)
 
-	// imageService := filesystem.NewImageStorageService("file_storage")
-	cloudinaryService := filesystem.NewCloudinaryService(cfg)
+	imageStorage, err := filesystem.NewImageStorage(cfg)
+	if err != nil {
+		log.Fatalf("Unable to create image storage: %q\n", err)
+	}
+	mailer := mail.NewMailer(cfg)
 
 	userService := app.NewUserService(userRepo)
-	sessionService := app.NewSessionService(sessionRepo, userService, tknAuth)
-	partyService := app.NewPartyService(partyRepo, cloudinaryService, userService)
-	memberService := app.NewMemberService(memberRepo, userService, partyService)
+	twoFactorService := app.NewTwoFactorService(userRepo, recoveryCodeRepo, cfg.TotpIssuer)
+	loginGuard := app.NewLoginGuard(loginAttemptRepo, cfg)
+	sessionService := app.NewSessionService(sessionRepo, userService, twoFactorService, loginGuard, userTokenRepo, refreshTokenRepo, mailer, tknAuth, cfg)
+	oidcProviders := make([]*oidc.Provider, len(cfg.OidcProviders))
+	for i, providerCfg := range cfg.OidcProviders {
+		oidcProviders[i] = oidc.NewProvider(providerCfg, cfg.ApiUrl)
+	}
+	paymentProvider, err := payments.NewProvider(cfg)
+	if err != nil {
+		log.Printf("Top-ups are disabled, unable to create payment provider: %s", err)
+	}
+	topUpService := app.NewTopUpService(topUpRepo, paymentProvider)
+	promoCodeService := app.NewPromoCodeService(promoCodeRepo)
+	ticketTierService := app.NewTicketTierService(ticketTierRepo)
+	transferService := app.NewTransferService(transferRepo, userRepo, memberRepo, cfg.TransferDailyLimit)
+	personalAccessTokenService := app.NewPersonalAccessTokenService(personalAccessTokenRepo)
+	adminService := app.NewAdminService(userRepo, sessionRepo, pointTransactionRepo)
+	oidcService := app.NewOidcService(oidcProviders, sessionService, userService, userIdentityRepo, oidcStateRepo, userTokenRepo)
+	webhookService := app.NewWebhookService(webhookRepo, webhookDeliveryRepo, webhookJobRepo)
+	partyService := app.NewPartyService(partyRepo, ticketTierRepo, partyImageRepo, uploadRepo, imageStorage, userService)
+	partySeriesService := app.NewPartySeriesService(partyRepo, imageStorage)
+	partyImageService := app.NewPartyImageService(partyImageRepo, memberRepo, partyRepo, uploadRepo, imageStorage)
+	uploadService := app.NewUploadService(uploadRepo, imageStorage, int64(cfg.UploadMaxBytes), int(cfg.ImageMaxDimension))
+	memberService := app.NewMemberService(memberRepo, promoCodeRepo, userService, partyService)
 	likeService := app.NewLikeService(likeRepo, userService)
+	calendarService := app.NewCalendarService(calendarFeedRepo, partyRepo, cfg.ApiUrl)
+
+	eventDispatcher := app.NewEventDispatcher(outboxRepo)
+	for _, eventType := range domain.WebhookEventTypes {
+		eventDispatcher.Subscribe(eventType, webhookService.HandleEvent)
+	}
 
 	userController := controllers.NewUserController(userService)
 	sessionController := controllers.NewSessionController(sessionService, userService)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+	oidcController := controllers.NewOidcController(oidcService, cfg.ApiUrl, cfg.FrontendUrl)
+	adminController := controllers.NewAdminController(adminService)
+	personalAccessTokenController := controllers.NewPersonalAccessTokenController(personalAccessTokenService)
+	topUpController := controllers.NewTopUpController(topUpService, paymentProvider)
+	transferController := controllers.NewTransferController(transferService)
+	promoCodeController := controllers.NewPromoCodeController(promoCodeService)
+	ticketTierController := controllers.NewTicketTierController(ticketTierService)
 	memberController := controllers.NewMemberController(memberService, partyService)
 	partyController := controllers.NewPartyController(partyService, memberService, userService)
+	partySeriesController := controllers.NewPartySeriesController(partySeriesService, userService)
+	partyImageController := controllers.NewPartyImageController(partyImageService)
+	uploadController := controllers.NewUploadController(uploadService, int64(cfg.UploadMaxBytes))
+	calendarController := controllers.NewCalendarController(calendarService, cfg.FrontendUrl)
+	feedController := controllers.NewFeedController(partyService, userService, cfg.ApiUrl, cfg.FrontendUrl)
 	likeController := controllers.NewLikeController(likeService)
+	webhookController := controllers.NewWebhookController(webhookService)
+	jwksController := controllers.NewJwksController(tknAuth)
 
-	authMiddleware := middlewares.AuthMiddleware(tknAuth, sessionService, userService)
+	authMiddleware := middlewares.AuthMiddleware(tknAuth, sessionService, userService, personalAccessTokenService)
+	realIpMiddleware := middlewares.RealIpMiddleware(cfg.TrustedProxies)
 
 	return Container{
 		Services: Services{
 			userService,
 			sessionService,
+			twoFactorService,
+			oidcService,
+			adminService,
+			personalAccessTokenService,
+			topUpService,
+			transferService,
+			promoCodeService,
+			ticketTierService,
 			partyService,
+			partySeriesService,
+			partyImageService,
+			uploadService,
+			calendarService,
 			memberService,
 			likeService,
+			webhookService,
+			eventDispatcher,
 		},
 		Controllers: Controllers{
 			userController,
 			sessionController,
+			twoFactorController,
+			oidcController,
+			adminController,
+			personalAccessTokenController,
+			topUpController,
+			transferController,
+			promoCodeController,
+			ticketTierController,
 			partyController,
+			partySeriesController,
+			partyImageController,
+			uploadController,
+			calendarController,
+			feedController,
 			memberController,
 			likeController,
+			webhookController,
+			jwksController,
 		},
 		Middleware: Middleware{
 			authMiddleware,
+			realIpMiddleware,
 		},
 	}
 }
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"log"
+)
+
+var (
+	ErrInvalidRole       = errors.New("invalid role")
+	ErrCannotManageSelf  = errors.New("you cannot change your own account")
+	ErrInsufficientRole  = errors.New("you cannot manage users with an equal or higher role")
+	ErrZeroBalanceAmount = errors.New("amount must not be zero")
+)
+
+type AdminService interface {
+	Find(id uint64) (domain.User, error)
+	SearchUsers(query string, page, limit int32) (domain.Users, error)
+	Ban(actor domain.User, user domain.User, ban domain.UserBan) (domain.User, error)
+	Unban(actor domain.User, user domain.User) (domain.User, error)
+	ChangeRole(actor domain.User, user domain.User, change domain.RoleChange) (domain.User, error)
+	AdjustBalance(actor domain.User, user domain.User, adjustment domain.BalanceAdjustment) (domain.PointTransaction, error)
+}
+
+type adminService struct {
+	userRepo             repositories.UserRepository
+	sessionRepo          repositories.SessionRepository
+	pointTransactionRepo repositories.PointTransactionRepository
+}
+
+func NewAdminService(ur repositories.UserRepository, sr repositories.SessionRepository, ptr repositories.PointTransactionRepository) AdminService {
+	return adminService{
+		userRepo:             ur,
+		sessionRepo:          sr,
+		pointTransactionRepo: ptr,
+	}
+}
+
+func (s adminService) Find(id uint64) (domain.User, error) {
+	return s.userRepo.FindById(id)
+}
+
+func (s adminService) SearchUsers(query string, page, limit int32) (domain.Users, error) {
+	users, err := s.userRepo.Search(query, page, limit)
+	if err != nil {
+		return domain.Users{}, err
+	}
+	return users, nil
+}
+
+func (s adminService) Ban(actor domain.User, user domain.User, ban domain.UserBan) (domain.User, error) {
+	err := s.checkCanManage(actor, user)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = s.userRepo.Ban(user.Id, ban.Reason)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = s.sessionRepo.DeleteByUserId(user.Id)
+	if err != nil {
+		return domain.User{}, err
+	}
+	log.Printf("AdminService: user %d banned user %d: %s", actor.Id, user.Id, ban.Reason)
+
+	return s.userRepo.FindById(user.Id)
+}
+
+func (s adminService) Unban(actor domain.User, user domain.User) (domain.User, error) {
+	err := s.checkCanManage(actor, user)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = s.userRepo.Unban(user.Id)
+	if err != nil {
+		return domain.User{}, err
+	}
+	log.Printf("AdminService: user %d unbanned user %d", actor.Id, user.Id)
+
+	return s.userRepo.FindById(user.Id)
+}
+
+func (s adminService) ChangeRole(actor domain.User, user domain.User, change domain.RoleChange) (domain.User, error) {
+	if !change.Role.IsValid() {
+		return domain.User{}, ErrInvalidRole
+	}
+	err := s.checkCanManage(actor, user)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = s.userRepo.UpdateRole(user.Id, change.Role)
+	if err != nil {
+		return domain.User{}, err
+	}
+	log.Printf("AdminService: user %d changed role of user %d to %s", actor.Id, user.Id, change.Role)
+
+	return s.userRepo.FindById(user.Id)
+}
+
+func (s adminService) AdjustBalance(actor domain.User, user domain.User, adjustment domain.BalanceAdjustment) (domain.PointTransaction, error) {
+	if adjustment.Amount == 0 {
+		return domain.PointTransaction{}, ErrZeroBalanceAmount
+	}
+
+	transaction, err := s.pointTransactionRepo.Save(domain.PointTransaction{
+		UserId:  user.Id,
+		Amount:  adjustment.Amount,
+		Type:    domain.PointTransactionAdminAdjustment,
+		Reason:  adjustment.Reason,
+		ActorId: &actor.Id,
+	})
+	if err != nil {
+		return domain.PointTransaction{}, err
+	}
+	return transaction, nil
+}
+
+func (s adminService) checkCanManage(actor domain.User, user domain.User) error {
+	if actor.Id == user.Id {
+		return ErrCannotManageSelf
+	}
+	if !actor.HasRole(domain.RoleAdmin) && user.HasRole(actor.Role) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"strings"
+	"time"
+)
+
+const (
+	calendarFeedTokenSize = 32
+	calendarPastWindow    = 90 * 24 * time.Hour
+	calendarFutureWindow  = 365 * 24 * time.Hour
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+type CalendarService interface {
+	FindFeed(token string) (domain.CalendarFeed, error)
+	FindUserParties(userId uint64) ([]domain.Party, error)
+	SaveFeed(userId uint64) (domain.CalendarFeed, error)
+	DeleteFeed(userId uint64) error
+}
+
+type calendarService struct {
+	feedRepo  repositories.CalendarFeedRepository
+	partyRepo repositories.PartyRepository
+	apiUrl    string
+}
+
+func NewCalendarService(feedRepo repositories.CalendarFeedRepository, partyRepo repositories.PartyRepository, apiUrl string) CalendarService {
+	return calendarService{
+		feedRepo:  feedRepo,
+		partyRepo: partyRepo,
+		apiUrl:    strings.TrimSuffix(apiUrl, "/"),
+	}
+}
+
+func (s calendarService) FindFeed(token string) (domain.CalendarFeed, error) {
+	feed, err := s.feedRepo.FindByHash(hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CalendarFeed{}, ErrCalendarFeedNotFound
+		}
+		return domain.CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+func (s calendarService) FindUserParties(userId uint64) ([]domain.Party, error) {
+	now := time.Now()
+	return s.partyRepo.FindJoinedOrHosted(userId, now.Add(-calendarPastWindow), now.Add(calendarFutureWindow))
+}
+
+func (s calendarService) SaveFeed(userId uint64) (domain.CalendarFeed, error) {
+	token, err := generateRandomToken(calendarFeedTokenSize)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+
+	feed, err := s.feedRepo.Save(domain.CalendarFeed{
+		UserId:    userId,
+		TokenHash: hashToken(token),
+	})
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	feed.Token = token
+	feed.Url = s.apiUrl + "/api/v1/calendar/" + token + ".ics"
+
+	return feed, nil
+}
+
+func (s calendarService) DeleteFeed(userId uint64) error {
+	return s.feedRepo.DeleteByUserId(userId)
+}
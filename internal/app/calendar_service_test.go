@@ -0,0 +1,36 @@
+package app
+
+import (
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"testing"
+	"time"
+)
+
+type calendarPartyRepo struct {
+	repositories.PartyRepository
+	from, to *time.Time
+}
+
+func (r calendarPartyRepo) FindJoinedOrHosted(userId uint64, from, to time.Time) ([]domain.Party, error) {
+	*r.from, *r.to = from, to
+	return []domain.Party{}, nil
+}
+
+func TestFindUserPartiesLimitsTheFeedToAWindowAroundNow(t *testing.T) {
+	partyRepo := calendarPartyRepo{from: &time.Time{}, to: &time.Time{}}
+	service := NewCalendarService(nil, partyRepo, "http://localhost:8080")
+
+	before := time.Now()
+	_, err := service.FindUserParties(7)
+	after := time.Now()
+	if err != nil {
+		t.Fatalf("FindUserParties() error = %v", err)
+	}
+	if now := partyRepo.from.Add(calendarPastWindow); now.Before(before) || now.After(after) {
+		t.Fatalf("from = %s, want %s before now", partyRepo.from, calendarPastWindow)
+	}
+	if now := partyRepo.to.Add(-calendarFutureWindow); now.Before(before) || now.After(after) {
+		t.Fatalf("to = %s, want %s after now", partyRepo.to, calendarFutureWindow)
+	}
+}
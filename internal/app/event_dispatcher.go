@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	outboxPollInterval = time.Second
+	outboxBatchSize    = 100
+)
+
+type EventHandler func(event domain.Event) error
+
+type EventDispatcher interface {
+	Subscribe(eventType string, handler EventHandler)
+	Run(ctx context.Context)
+}
+
+type eventDispatcher struct {
+	outboxRepo repositories.OutboxRepository
+	mu         sync.RWMutex
+	handlers   map[string][]EventHandler
+}
+
+func NewEventDispatcher(outboxRepo repositories.OutboxRepository) EventDispatcher {
+	return &eventDispatcher{
+		outboxRepo: outboxRepo,
+		handlers:   map[string][]EventHandler{},
+	}
+}
+
+func (d *eventDispatcher) Subscribe(eventType string, handler EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+func (d *eventDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.dispatchPending()
+		}
+	}
+}
+
+func (d *eventDispatcher) dispatchPending() {
+	events, err := d.outboxRepo.FindPending(outboxBatchSize)
+	if err != nil {
+		log.Printf("Event dispatcher dispatchPending.FindPending: %s", err)
+		return
+	}
+
+events:
+	for _, event := range events {
+		d.mu.RLock()
+		handlers := d.handlers[event.Type]
+		d.mu.RUnlock()
+
+		for _, handler := range handlers {
+			err = handler(event)
+			if err != nil {
+				log.Printf("Event dispatcher handler for %s (event %d): %s", event.Type, event.Id, err)
+				continue events
+			}
+		}
+
+		err = d.outboxRepo.MarkProcessed(event.Id)
+		if err != nil {
+			log.Printf("Event dispatcher dispatchPending.MarkProcessed: %s", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"errors"
+	"go-rest-api/internal/domain"
+	"slices"
+	"testing"
+)
+
+type memoryOutboxRepo struct {
+	events    []domain.Event
+	processed *[]uint64
+}
+
+func (r memoryOutboxRepo) FindPending(limit int32) ([]domain.Event, error) {
+	return r.events, nil
+}
+
+func (r memoryOutboxRepo) MarkProcessed(id uint64) error {
+	*r.processed = append(*r.processed, id)
+	return nil
+}
+
+func TestEventDispatcherKeepsFailedEventsPending(t *testing.T) {
+	outboxRepo := memoryOutboxRepo{
+		events:    []domain.Event{{Id: 1, Type: "party.created"}, {Id: 2, Type: "party.created"}},
+		processed: &[]uint64{},
+	}
+	dispatcher := NewEventDispatcher(outboxRepo).(*eventDispatcher)
+	dispatcher.Subscribe("party.created", func(event domain.Event) error {
+		if event.Id == 1 {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+
+	dispatcher.dispatchPending()
+
+	if !slices.Equal(*outboxRepo.processed, []uint64{2}) {
+		t.Fatalf("processed = %v, want only event 2", *outboxRepo.processed)
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	accountFreeLoginAttempts = 3
+	ipFreeLoginAttempts      = 10
+)
+
+var ErrTooManyLoginAttempts = errors.New("too many login attempts")
+
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e LoginThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrTooManyLoginAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e LoginThrottledError) Is(target error) bool {
+	return target == ErrTooManyLoginAttempts
+}
+
+type LoginGuard interface {
+	Check(email string, client domain.ClientInfo) error
+	RecordFailure(email string, userId *uint64, client domain.ClientInfo, reason string)
+	RecordSuccess(email string, userId uint64, client domain.ClientInfo)
+}
+
+type loginGuard struct {
+	loginAttemptRepo   repositories.LoginAttemptRepository
+	maxAccountAttempts int32
+	maxIpAttempts      int32
+	lockoutDuration    time.Duration
+}
+
+func NewLoginGuard(lar repositories.LoginAttemptRepository, cfg config.Configuration) LoginGuard {
+	return loginGuard{
+		loginAttemptRepo:   lar,
+		maxAccountAttempts: cfg.LoginMaxAccountAttempts,
+		maxIpAttempts:      cfg.LoginMaxIpAttempts,
+		lockoutDuration:    cfg.LoginLockoutDuration,
+	}
+}
+
+func (g loginGuard) Check(email string, client domain.ClientInfo) error {
+	email = normalizeLoginEmail(email)
+
+	accountStats, err := g.loginAttemptRepo.StatsByEmail(email, g.lockoutDuration)
+	if err != nil {
+		return err
+	}
+	ipStats, err := g.loginAttemptRepo.StatsByIp(client.Ip, g.lockoutDuration)
+	if err != nil {
+		return err
+	}
+
+	retryAfter := max(
+		g.retryAfter(accountStats, accountFreeLoginAttempts, g.maxAccountAttempts),
+		g.retryAfter(ipStats, ipFreeLoginAttempts, g.maxIpAttempts),
+	)
+	if retryAfter > 0 {
+		g.save(domain.LoginAttempt{
+			Email:     email,
+			Ip:        client.Ip,
+			UserAgent: client.UserAgent,
+			Reason:    domain.LoginFailureThrottled,
+		})
+		return LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func (g loginGuard) RecordFailure(email string, userId *uint64, client domain.ClientInfo, reason string) {
+	g.save(domain.LoginAttempt{
+		UserId:    userId,
+		Email:     normalizeLoginEmail(email),
+		Ip:        client.Ip,
+		UserAgent: client.UserAgent,
+		Reason:    reason,
+	})
+}
+
+func (g loginGuard) RecordSuccess(email string, userId uint64, client domain.ClientInfo) {
+	g.save(domain.LoginAttempt{
+		UserId:    &userId,
+		Email:     normalizeLoginEmail(email),
+		Ip:        client.Ip,
+		UserAgent: client.UserAgent,
+		Success:   true,
+	})
+}
+
+func (g loginGuard) retryAfter(stats domain.LoginAttemptStats, free, maximum int32) time.Duration {
+	if stats.Failures <= free {
+		return 0
+	}
+
+	delay := g.lockoutDuration
+	if stats.Failures < maximum {
+		shift := min(stats.Failures-free-1, 20)
+		delay = min(time.Second<<shift, g.lockoutDuration)
+	}
+	return max(delay-stats.SinceLastFailure, 0)
+}
+
+func (g loginGuard) save(attempt domain.LoginAttempt) {
+	_, err := g.loginAttemptRepo.Save(attempt)
+	if err != nil {
+		log.Printf("LoginGuard: failed to save login attempt %s", err)
+	}
+}
+
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
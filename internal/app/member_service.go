@@ -1,39 +1,102 @@
 package app
 
 import (
+	"database/sql"
+	"errors"
 	"go-rest-api/internal/domain"
 	"go-rest-api/internal/infra/database/repositories"
+	"time"
 )
 
 type MemberService interface {
-	Save(domainMember domain.Member) error
+	Save(domainMember domain.Member, promoCode string) (domain.Member, error)
 	Exists(domainMember domain.Member) error
 	Delete(domainMember domain.Member) error
 	FindByUserId(userId uint64) ([]domain.Party, error)
-	FindByPartyId(partyId uint64) ([]domain.User, error)
+	FindByPartyId(partyId uint64) ([]domain.PartyMember, error)
 }
 
 type memberService struct {
-	memberRepo   repositories.MemberRepository
-	userService  UserService
-	partyService PartyService
+	memberRepo    repositories.MemberRepository
+	promoCodeRepo repositories.PromoCodeRepository
+	userService   UserService
+	partyService  PartyService
 }
 
-func NewMemberService(memberRepo repositories.MemberRepository, userService UserService, partyService PartyService) MemberService {
+func NewMemberService(memberRepo repositories.MemberRepository, promoCodeRepo repositories.PromoCodeRepository, userService UserService, partyService PartyService) MemberService {
 	return memberService{
-		memberRepo:   memberRepo,
-		userService:  userService,
-		partyService: partyService,
+		memberRepo:    memberRepo,
+		promoCodeRepo: promoCodeRepo,
+		userService:   userService,
+		partyService:  partyService,
 	}
 }
 
-func (m memberService) Save(domainMember domain.Member) error {
-	err := m.memberRepo.Save(domainMember)
+func (m memberService) Save(domainMember domain.Member, promoCode string) (domain.Member, error) {
+	party, err := m.partyService.FindById(domainMember.PartyId)
 	if err != nil {
-		return err
+		return domain.Member{}, err
 	}
+	domainMember.Price = party.Price
 
-	return nil
+	if party.HasTiers() {
+		if domainMember.TierId == nil {
+			return domain.Member{}, ErrTierRequired
+		}
+		tier, ok := party.FindTier(*domainMember.TierId)
+		if !ok {
+			return domain.Member{}, ErrTierNotFound
+		}
+		if tier.Remaining() == 0 {
+			return domain.Member{}, repositories.ErrTierSoldOut
+		}
+		domainMember.Price = tier.Price
+	} else if domainMember.TierId != nil {
+		return domain.Member{}, ErrTierNotFound
+	}
+	basePrice := domainMember.Price
+
+	var redemption *domain.PromoRedemption
+	if promoCode != "" {
+		code, err := m.promoCodeRepo.FindByCode(party.Id, normalizePromoCode(promoCode))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.Member{}, ErrPromoCodeNotFound
+			}
+			return domain.Member{}, err
+		}
+		if !code.IsActive(time.Now()) {
+			return domain.Member{}, repositories.ErrPromoCodeUnavailable
+		}
+
+		domainMember.Price = code.Apply(basePrice)
+		redemption = &domain.PromoRedemption{
+			PromoCodeId: code.Id,
+			UserId:      domainMember.UserId,
+			Price:       domainMember.Price,
+			Discount:    basePrice - domainMember.Price,
+		}
+	}
+
+	if domainMember.Price < 0 {
+		domainMember.Price = 0
+	}
+	if domainMember.Price > 0 {
+		user, err := m.userService.FindById(domainMember.UserId)
+		if err != nil {
+			return domain.Member{}, err
+		}
+		if !user.Verified {
+			return domain.Member{}, ErrEmailNotVerified
+		}
+	}
+
+	err = m.memberRepo.Save(domainMember, redemption)
+	if err != nil {
+		return domain.Member{}, err
+	}
+
+	return domainMember, nil
 }
 
 func (m memberService) FindByUserId(userId uint64) ([]domain.Party, error) {
@@ -55,23 +118,23 @@ func (m memberService) FindByUserId(userId uint64) ([]domain.Party, error) {
 	return parties, nil
 }
 
-func (m memberService) FindByPartyId(partyId uint64) ([]domain.User, error) {
+func (m memberService) FindByPartyId(partyId uint64) ([]domain.PartyMember, error) {
 	members, err := m.memberRepo.FindByPartyId(partyId)
 	if err != nil {
-		return []domain.User{}, err
+		return []domain.PartyMember{}, err
 	}
 
-	users := []domain.User{}
+	partyMembers := []domain.PartyMember{}
 
 	for _, member := range members {
 		user, err := m.userService.FindById(member.UserId)
 		if err != nil {
-			return []domain.User{}, err
+			return []domain.PartyMember{}, err
 		}
-		users = append(users, user)
+		partyMembers = append(partyMembers, domain.PartyMember{User: user, TierId: member.TierId})
 	}
 
-	return users, nil
+	return partyMembers, nil
 }
 
 func (m memberService) Delete(domainMember domain.Member) error {
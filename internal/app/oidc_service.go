@@ -0,0 +1,236 @@
+package app
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/oidc"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	oidcStateTTL     = 10 * time.Minute
+	oidcLoginCodeTTL = time.Minute
+)
+
+var (
+	ErrUnknownOidcProvider  = errors.New("unknown identity provider")
+	ErrInvalidOidcState     = errors.New("invalid or expired login state")
+	ErrOidcEmailNotVerified = errors.New("identity provider did not return a verified email")
+	ErrOidcAccountConflict  = errors.New("an unverified account with this email already exists")
+)
+
+type OidcService interface {
+	AuthorizationUrl(provider string) (string, string, error)
+	Callback(callback domain.OidcCallback) (string, error)
+	Exchange(exchange domain.OidcExchange, client domain.ClientInfo) (domain.User, domain.AuthTokens, error)
+}
+
+type oidcService struct {
+	providers     map[string]*oidc.Provider
+	sessionServ   SessionService
+	userServ      UserService
+	identityRepo  repositories.UserIdentityRepository
+	oidcStateRepo repositories.OidcStateRepository
+	userTokenRepo repositories.UserTokenRepository
+}
+
+func NewOidcService(providers []*oidc.Provider, ss SessionService, us UserService, ir repositories.UserIdentityRepository, osr repositories.OidcStateRepository, utr repositories.UserTokenRepository) OidcService {
+	byName := make(map[string]*oidc.Provider, len(providers))
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+
+	return oidcService{
+		providers:     byName,
+		sessionServ:   ss,
+		userServ:      us,
+		identityRepo:  ir,
+		oidcStateRepo: osr,
+		userTokenRepo: utr,
+	}
+}
+
+func (s oidcService) AuthorizationUrl(providerName string) (string, string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", ErrUnknownOidcProvider
+	}
+
+	state, err := generateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	_, err = s.oidcStateRepo.Save(domain.OidcState{
+		StateHash:    hashToken(state),
+		Provider:     providerName,
+		CodeVerifier: codeVerifier,
+		ExpiresDate:  time.Now().Add(oidcStateTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	authUrl, err := provider.AuthCodeUrl(state, codeVerifier)
+	if err != nil {
+		return "", "", err
+	}
+	return authUrl, state, nil
+}
+
+// Callback returns a one-time code the frontend exchanges for the session, so
+// the tokens never end up in the browser's address bar or history.
+func (s oidcService) Callback(callback domain.OidcCallback) (string, error) {
+	provider, ok := s.providers[callback.Provider]
+	if !ok {
+		return "", ErrUnknownOidcProvider
+	}
+	if callback.BrowserState == "" || subtle.ConstantTimeCompare([]byte(callback.State), []byte(callback.BrowserState)) != 1 {
+		return "", ErrInvalidOidcState
+	}
+
+	state, err := s.oidcStateRepo.Consume(hashToken(callback.State))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrInvalidOidcState
+		}
+		return "", err
+	}
+	if state.IsExpired() || state.Provider != callback.Provider {
+		return "", ErrInvalidOidcState
+	}
+
+	accessToken, err := provider.Exchange(callback.Code, state.CodeVerifier)
+	if err != nil {
+		return "", err
+	}
+	profile, err := provider.UserInfo(accessToken)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := s.findOrLinkUser(callback.Provider, profile)
+	if err != nil {
+		return "", err
+	}
+	if user.IsBanned() {
+		return "", ErrUserBanned
+	}
+
+	code, err := generateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	_, err = s.userTokenRepo.Save(domain.UserToken{
+		UserId:      user.Id,
+		Purpose:     domain.OidcLoginPurpose,
+		TokenHash:   hashToken(code),
+		ExpiresDate: time.Now().Add(oidcLoginCodeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+func (s oidcService) Exchange(exchange domain.OidcExchange, client domain.ClientInfo) (domain.User, domain.AuthTokens, error) {
+	userToken, err := s.userTokenRepo.FindByHash(domain.OidcLoginPurpose, hashToken(exchange.Code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+		}
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+	if userToken.UsedDate != nil || userToken.IsExpired() {
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+	}
+	err = s.userTokenRepo.MarkUsed(userToken.Id)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+	}
+
+	user, err := s.userServ.FindById(userToken.UserId)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+	return s.sessionServ.LoginExternal(user, client)
+}
+
+func (s oidcService) findOrLinkUser(providerName string, profile domain.OidcProfile) (domain.User, error) {
+	identity, err := s.identityRepo.FindByProviderAndSubject(providerName, profile.Subject)
+	if err == nil {
+		return s.userServ.FindById(identity.UserId)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, err
+	}
+
+	if profile.Email == "" || !profile.EmailVerified {
+		return domain.User{}, ErrOidcEmailNotVerified
+	}
+
+	user, err := s.userServ.FindByEmail(profile.Email)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, err
+		}
+		user, err = s.createUser(profile)
+		if err != nil {
+			return domain.User{}, err
+		}
+	} else if !user.Verified {
+		// Linking would hand the account to someone who never proved owning the email.
+		return domain.User{}, ErrOidcAccountConflict
+	}
+
+	_, err = s.identityRepo.Save(domain.UserIdentity{
+		UserId:   user.Id,
+		Provider: providerName,
+		Subject:  profile.Subject,
+		Email:    profile.Email,
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+	log.Printf("OidcService: linked %s identity to user %d", providerName, user.Id)
+
+	return user, nil
+}
+
+func (s oidcService) createUser(profile domain.OidcProfile) (domain.User, error) {
+	password, err := generateRandomToken(32)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	name := profile.Name
+	if name == "" {
+		name, _, _ = strings.Cut(profile.Email, "@")
+	}
+
+	user, err := s.userServ.Save(domain.User{
+		Name:     name,
+		Email:    profile.Email,
+		Password: password,
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = s.userServ.MarkVerified(user)
+	if err != nil {
+		return domain.User{}, err
+	}
+	user.Verified = true
+
+	return user, nil
+}
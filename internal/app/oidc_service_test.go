@@ -0,0 +1,327 @@
+package app
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"go-rest-api/config"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/oidc"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubOidcProvider is a minimal OIDC provider that checks the PKCE verifier
+// against the challenge sent to its authorization endpoint.
+type stubOidcProvider struct {
+	*httptest.Server
+	mu         sync.Mutex
+	challenges map[string]string
+	profile    map[string]any
+}
+
+func newStubOidcProvider(t *testing.T, profile map[string]any) *stubOidcProvider {
+	stub := &stubOidcProvider{challenges: map[string]string{}, profile: profile}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"authorization_endpoint": stub.URL + "/authorize",
+			"token_endpoint":         stub.URL + "/token",
+			"userinfo_endpoint":      stub.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		stub.mu.Lock()
+		challenge, ok := stub.challenges[r.PostFormValue("code")]
+		delete(stub.challenges, r.PostFormValue("code"))
+		stub.mu.Unlock()
+		if !ok || oidc.CodeChallenge(r.PostFormValue("code_verifier")) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(stub.profile)
+	})
+	stub.Server = httptest.NewServer(mux)
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+// authorize plays the user consenting at the provider, it returns the code
+// and state the provider would redirect back with.
+func (s *stubOidcProvider) authorize(t *testing.T, authorizationUrl string) (string, string) {
+	parsed, err := url.Parse(authorizationUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("authorization url %s has no S256 code challenge", authorizationUrl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	code := "code-" + query.Get("state")[:8]
+	s.challenges[code] = query.Get("code_challenge")
+	return code, query.Get("state")
+}
+
+type memoryOidcStateRepo struct {
+	states map[string]domain.OidcState
+}
+
+func (r memoryOidcStateRepo) Save(state domain.OidcState) (domain.OidcState, error) {
+	r.states[state.StateHash] = state
+	return state, nil
+}
+
+func (r memoryOidcStateRepo) Consume(stateHash string) (domain.OidcState, error) {
+	state, ok := r.states[stateHash]
+	if !ok {
+		return domain.OidcState{}, sql.ErrNoRows
+	}
+	delete(r.states, stateHash)
+	return state, nil
+}
+
+type memoryUserIdentityRepo struct {
+	identities *[]domain.UserIdentity
+}
+
+func (r memoryUserIdentityRepo) Save(identity domain.UserIdentity) (domain.UserIdentity, error) {
+	*r.identities = append(*r.identities, identity)
+	return identity, nil
+}
+
+func (r memoryUserIdentityRepo) FindByProviderAndSubject(provider, subject string) (domain.UserIdentity, error) {
+	for _, identity := range *r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return domain.UserIdentity{}, sql.ErrNoRows
+}
+
+type memoryUserTokenRepo struct {
+	repositories.UserTokenRepository
+	tokens map[string]*domain.UserToken
+}
+
+func (r memoryUserTokenRepo) Save(token domain.UserToken) (domain.UserToken, error) {
+	token.Id = uint64(len(r.tokens) + 1)
+	r.tokens[token.TokenHash] = &token
+	return token, nil
+}
+
+func (r memoryUserTokenRepo) FindByHash(purpose, tokenHash string) (domain.UserToken, error) {
+	token, ok := r.tokens[tokenHash]
+	if !ok || token.Purpose != purpose {
+		return domain.UserToken{}, sql.ErrNoRows
+	}
+	return *token, nil
+}
+
+func (r memoryUserTokenRepo) MarkUsed(id uint64) error {
+	for _, token := range r.tokens {
+		if token.Id == id && token.UsedDate == nil {
+			now := time.Now()
+			token.UsedDate = &now
+			return nil
+		}
+	}
+	return errors.New("token already used")
+}
+
+type memoryUserService struct {
+	UserService
+	users map[string]domain.User
+}
+
+func (s memoryUserService) FindByEmail(email string) (domain.User, error) {
+	user, ok := s.users[email]
+	if !ok {
+		return domain.User{}, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (s memoryUserService) FindById(id uint64) (domain.User, error) {
+	for _, user := range s.users {
+		if user.Id == id {
+			return user, nil
+		}
+	}
+	return domain.User{}, sql.ErrNoRows
+}
+
+type externalLoginSessionService struct {
+	SessionService
+}
+
+func (s externalLoginSessionService) LoginExternal(user domain.User, client domain.ClientInfo) (domain.User, domain.AuthTokens, error) {
+	return user, domain.AuthTokens{}, nil
+}
+
+type oidcTest struct {
+	stub       *stubOidcProvider
+	service    OidcService
+	identities *[]domain.UserIdentity
+}
+
+func newOidcTest(t *testing.T, profile map[string]any, users ...domain.User) oidcTest {
+	stub := newStubOidcProvider(t, profile)
+	provider := oidc.NewProvider(config.OidcProvider{
+		Name:         "stub",
+		Issuer:       stub.URL,
+		ClientId:     "client",
+		SubjectClaim: "sub",
+		Scopes:       []string{"openid", "email"},
+	}, "http://localhost:8080")
+
+	byEmail := map[string]domain.User{}
+	for _, user := range users {
+		byEmail[user.Email] = user
+	}
+	identities := &[]domain.UserIdentity{}
+	service := NewOidcService(
+		[]*oidc.Provider{provider},
+		externalLoginSessionService{},
+		memoryUserService{users: byEmail},
+		memoryUserIdentityRepo{identities: identities},
+		memoryOidcStateRepo{states: map[string]domain.OidcState{}},
+		memoryUserTokenRepo{tokens: map[string]*domain.UserToken{}},
+	)
+	return oidcTest{stub: stub, service: service, identities: identities}
+}
+
+// start begins a login and returns the callback the provider redirects back
+// with, as sent by the browser holding the state cookie.
+func (o oidcTest) start(t *testing.T) domain.OidcCallback {
+	authorizationUrl, browserState, err := o.service.AuthorizationUrl("stub")
+	if err != nil {
+		t.Fatalf("AuthorizationUrl() error = %v", err)
+	}
+	code, state := o.stub.authorize(t, authorizationUrl)
+	return domain.OidcCallback{Provider: "stub", Code: code, State: state, BrowserState: browserState}
+}
+
+func (o oidcTest) login(t *testing.T) (domain.OidcCallback, domain.User, error) {
+	callback := o.start(t)
+	code, err := o.service.Callback(callback)
+	if err != nil {
+		return callback, domain.User{}, err
+	}
+	user, _, err := o.service.Exchange(domain.OidcExchange{Code: code}, domain.ClientInfo{})
+	return callback, user, err
+}
+
+func verifiedProfile() map[string]any {
+	return map[string]any{"sub": "stub-1", "email": "alice@example.com", "email_verified": true, "name": "Alice"}
+}
+
+func TestOidcCallbackSendsPkceVerifier(t *testing.T) {
+	o := newOidcTest(t, verifiedProfile(), domain.User{Id: 5, Email: "alice@example.com", Verified: true})
+
+	callback := o.start(t)
+	// A code issued for another challenge must not be redeemable with our verifier.
+	o.stub.mu.Lock()
+	o.stub.challenges[callback.Code] = oidc.CodeChallenge("someone-elses-verifier")
+	o.stub.mu.Unlock()
+
+	_, err := o.service.Callback(callback)
+	if !errors.Is(err, oidc.ErrExchangeFailed) {
+		t.Fatalf("Callback() error = %v, want %v", err, oidc.ErrExchangeFailed)
+	}
+
+	_, _, err = o.login(t)
+	if err != nil {
+		t.Fatalf("login with the matching verifier error = %v", err)
+	}
+}
+
+func TestOidcStateCanOnlyBeUsedOnce(t *testing.T) {
+	o := newOidcTest(t, verifiedProfile(), domain.User{Id: 5, Email: "alice@example.com", Verified: true})
+
+	callback, _, err := o.login(t)
+	if err != nil {
+		t.Fatalf("login error = %v", err)
+	}
+
+	_, err = o.service.Callback(callback)
+	if !errors.Is(err, ErrInvalidOidcState) {
+		t.Fatalf("second Callback() error = %v, want %v", err, ErrInvalidOidcState)
+	}
+}
+
+func TestOidcStateMustComeFromTheSameBrowser(t *testing.T) {
+	o := newOidcTest(t, verifiedProfile(), domain.User{Id: 5, Email: "alice@example.com", Verified: true})
+
+	for _, browserState := range []string{"", "someone-elses-state"} {
+		callback := o.start(t)
+		callback.BrowserState = browserState
+		_, err := o.service.Callback(callback)
+		if !errors.Is(err, ErrInvalidOidcState) {
+			t.Fatalf("Callback() with browser state %q error = %v, want %v", browserState, err, ErrInvalidOidcState)
+		}
+	}
+}
+
+func TestOidcLoginCodeCanOnlyBeExchangedOnce(t *testing.T) {
+	o := newOidcTest(t, verifiedProfile(), domain.User{Id: 5, Email: "alice@example.com", Verified: true})
+
+	code, err := o.service.Callback(o.start(t))
+	if err != nil {
+		t.Fatalf("Callback() error = %v", err)
+	}
+	_, _, err = o.service.Exchange(domain.OidcExchange{Code: code}, domain.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+
+	_, _, err = o.service.Exchange(domain.OidcExchange{Code: code}, domain.ClientInfo{})
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("second Exchange() error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestOidcLinksIdentityByVerifiedEmail(t *testing.T) {
+	o := newOidcTest(t, verifiedProfile(), domain.User{Id: 5, Email: "alice@example.com", Verified: true})
+
+	_, user, err := o.login(t)
+	if err != nil {
+		t.Fatalf("login error = %v", err)
+	}
+	if user.Id != 5 {
+		t.Fatalf("Exchange() user = %d, want 5", user.Id)
+	}
+
+	identities := *o.identities
+	if len(identities) != 1 || identities[0].UserId != 5 || identities[0].Subject != "stub-1" || identities[0].Provider != "stub" {
+		t.Fatalf("identities = %+v, want stub-1 linked to user 5", identities)
+	}
+}
+
+func TestOidcRejectsUnverifiedEmail(t *testing.T) {
+	profile := verifiedProfile()
+	profile["email_verified"] = false
+	o := newOidcTest(t, profile, domain.User{Id: 5, Email: "alice@example.com", Verified: true})
+
+	_, _, err := o.login(t)
+	if !errors.Is(err, ErrOidcEmailNotVerified) {
+		t.Fatalf("Callback() error = %v, want %v", err, ErrOidcEmailNotVerified)
+	}
+	if len(*o.identities) != 0 {
+		t.Fatalf("identities = %+v, want none", *o.identities)
+	}
+}
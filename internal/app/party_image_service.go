@@ -0,0 +1,201 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/filesystem"
+	"log"
+)
+
+var (
+	ErrImageNotFound      = errors.New("image not found")
+	ErrTooManyImages      = fmt.Errorf("a party can have at most %d images", domain.MaxPartyImages)
+	ErrGalleryFull        = fmt.Errorf("a party gallery can have at most %d photos", domain.MaxGalleryImages)
+	ErrImageOrderMismatch = errors.New("imageIds must list every party image exactly once")
+	ErrGalleryNotOpen     = errors.New("photos can be shared once the party is finished")
+	ErrNotPartyAttendee   = errors.New("only party attendees can share photos")
+	ErrImageForbidden     = errors.New("only the host or the uploader can remove this photo")
+)
+
+type PartyImageService interface {
+	FindByPartyId(partyId uint64, kind string, withPending bool) ([]domain.PartyImage, error)
+	SaveHostImage(party domain.Party, image domain.PartyImage) (domain.PartyImage, error)
+	SaveGalleryPhoto(party domain.Party, image domain.PartyImage) (domain.PartyImage, error)
+	Reorder(partyId uint64, ids []uint64) ([]domain.PartyImage, error)
+	SetCover(partyId, id uint64) ([]domain.PartyImage, error)
+	Approve(partyId, id uint64) (domain.PartyImage, error)
+	Delete(party domain.Party, kind string, userId, id uint64) error
+}
+
+type partyImageService struct {
+	imageRepo    repositories.PartyImageRepository
+	memberRepo   repositories.MemberRepository
+	partyRepo    repositories.PartyRepository
+	uploadRepo   repositories.UploadRepository
+	imageStorage filesystem.ImageStorage
+}
+
+func NewPartyImageService(imageRepo repositories.PartyImageRepository, memberRepo repositories.MemberRepository, partyRepo repositories.PartyRepository, uploadRepo repositories.UploadRepository, imageStorage filesystem.ImageStorage) PartyImageService {
+	return partyImageService{
+		imageRepo:    imageRepo,
+		memberRepo:   memberRepo,
+		partyRepo:    partyRepo,
+		uploadRepo:   uploadRepo,
+		imageStorage: imageStorage,
+	}
+}
+
+func (s partyImageService) FindByPartyId(partyId uint64, kind string, withPending bool) ([]domain.PartyImage, error) {
+	images, err := s.imageRepo.FindByPartyId(partyId, kind)
+	if err != nil {
+		return []domain.PartyImage{}, err
+	}
+	if withPending {
+		return images, nil
+	}
+
+	approved := []domain.PartyImage{}
+	for _, image := range images {
+		if image.IsApproved() {
+			approved = append(approved, image)
+		}
+	}
+	return approved, nil
+}
+
+func (s partyImageService) SaveHostImage(party domain.Party, image domain.PartyImage) (domain.PartyImage, error) {
+	count, err := s.imageRepo.Count(party.Id, domain.PartyImageKindHost)
+	if err != nil {
+		return domain.PartyImage{}, err
+	}
+	if count >= domain.MaxPartyImages {
+		return domain.PartyImage{}, ErrTooManyImages
+	}
+
+	image.PartyId = party.Id
+	image.UserId = party.CreatorId
+	image.Kind = domain.PartyImageKindHost
+	image.Status = domain.PartyImageStatusApproved
+	return s.save(image)
+}
+
+func (s partyImageService) SaveGalleryPhoto(party domain.Party, image domain.PartyImage) (domain.PartyImage, error) {
+	if !party.IsFinished() {
+		return domain.PartyImage{}, ErrGalleryNotOpen
+	}
+
+	image.Status = domain.PartyImageStatusApproved
+	if image.UserId != party.CreatorId {
+		err := s.memberRepo.Exists(domain.Member{PartyId: party.Id, UserId: image.UserId})
+		if err != nil {
+			return domain.PartyImage{}, ErrNotPartyAttendee
+		}
+		image.Status = domain.PartyImageStatusPending
+	}
+
+	count, err := s.imageRepo.Count(party.Id, domain.PartyImageKindGallery)
+	if err != nil {
+		return domain.PartyImage{}, err
+	}
+	if count >= domain.MaxGalleryImages {
+		return domain.PartyImage{}, ErrGalleryFull
+	}
+
+	image.PartyId = party.Id
+	image.Kind = domain.PartyImageKindGallery
+	return s.save(image)
+}
+
+func (s partyImageService) save(image domain.PartyImage) (domain.PartyImage, error) {
+	upload, err := takeImageUpload(s.uploadRepo, image.UploadId, image.UserId)
+	if err != nil {
+		return domain.PartyImage{}, err
+	}
+	image.Url, image.MediumUrl, image.ThumbnailUrl = upload.Url, upload.MediumUrl, upload.ThumbnailUrl
+
+	image, err = s.imageRepo.Save(image)
+	if err != nil {
+		log.Printf("Party image service save.RepoSave: %s", err)
+		deleteUnusedImage(s.partyRepo, s.imageStorage, upload.Url, upload.MediumUrl, upload.ThumbnailUrl)
+		return domain.PartyImage{}, err
+	}
+	return image, nil
+}
+
+func (s partyImageService) Reorder(partyId uint64, ids []uint64) ([]domain.PartyImage, error) {
+	images, err := s.imageRepo.FindByPartyId(partyId, domain.PartyImageKindHost)
+	if err != nil {
+		return []domain.PartyImage{}, err
+	}
+	if len(ids) != len(images) {
+		return []domain.PartyImage{}, ErrImageOrderMismatch
+	}
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return []domain.PartyImage{}, ErrImageOrderMismatch
+		}
+		seen[id] = true
+	}
+
+	err = s.imageRepo.Reorder(partyId, ids)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.PartyImage{}, ErrImageOrderMismatch
+		}
+		return []domain.PartyImage{}, err
+	}
+	return s.imageRepo.FindByPartyId(partyId, domain.PartyImageKindHost)
+}
+
+func (s partyImageService) SetCover(partyId, id uint64) ([]domain.PartyImage, error) {
+	err := s.imageRepo.SetCover(partyId, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.PartyImage{}, ErrImageNotFound
+		}
+		return []domain.PartyImage{}, err
+	}
+	return s.imageRepo.FindByPartyId(partyId, domain.PartyImageKindHost)
+}
+
+func (s partyImageService) Approve(partyId, id uint64) (domain.PartyImage, error) {
+	image, err := s.imageRepo.FindById(partyId, id)
+	if err != nil || image.Kind != domain.PartyImageKindGallery {
+		return domain.PartyImage{}, s.notFound(err)
+	}
+
+	image, err = s.imageRepo.Approve(partyId, id)
+	if err != nil {
+		return domain.PartyImage{}, s.notFound(err)
+	}
+	return image, nil
+}
+
+func (s partyImageService) Delete(party domain.Party, kind string, userId, id uint64) error {
+	image, err := s.imageRepo.FindById(party.Id, id)
+	if err != nil || image.Kind != kind {
+		return s.notFound(err)
+	}
+	if userId != party.CreatorId && userId != image.UserId {
+		return ErrImageForbidden
+	}
+
+	image, err = s.imageRepo.Delete(party.Id, id)
+	if err != nil {
+		return s.notFound(err)
+	}
+
+	deleteUnusedImage(s.partyRepo, s.imageStorage, image.Url, image.MediumUrl, image.ThumbnailUrl)
+	return nil
+}
+
+func (s partyImageService) notFound(err error) error {
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return ErrImageNotFound
+	}
+	return err
+}
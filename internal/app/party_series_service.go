@@ -0,0 +1,48 @@
+package app
+
+import (
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/filesystem"
+)
+
+type PartySeriesService interface {
+	Find(id uint64) (domain.PartySeries, error)
+	Cancel(series domain.PartySeries) ([]domain.Party, error)
+}
+
+type partySeriesService struct {
+	partyRepo    repositories.PartyRepository
+	imageStorage filesystem.ImageStorage
+}
+
+func NewPartySeriesService(partyRepo repositories.PartyRepository, imageStorage filesystem.ImageStorage) PartySeriesService {
+	return partySeriesService{
+		partyRepo:    partyRepo,
+		imageStorage: imageStorage,
+	}
+}
+
+func (s partySeriesService) Find(id uint64) (domain.PartySeries, error) {
+	series, err := s.partyRepo.FindSeriesById(id)
+	if err != nil {
+		return domain.PartySeries{}, err
+	}
+	return series, nil
+}
+
+func (s partySeriesService) Cancel(series domain.PartySeries) ([]domain.Party, error) {
+	parties, err := s.partyRepo.CancelSeries(series.Id)
+	if err != nil {
+		return []domain.Party{}, err
+	}
+
+	images := map[string]bool{}
+	for _, party := range parties {
+		if !images[party.Image] {
+			images[party.Image] = true
+			deleteUnusedImage(s.partyRepo, s.imageStorage, party.Image, party.ImageMedium, party.ImageThumbnail)
+		}
+	}
+	return parties, nil
+}
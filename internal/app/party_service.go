@@ -1,36 +1,52 @@
 package app
 
 import (
+	"database/sql"
+	"errors"
 	"go-rest-api/internal/domain"
 	"go-rest-api/internal/infra/database/repositories"
 	"go-rest-api/internal/infra/filesystem"
 	"log"
-	"strconv"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSeriesScope = errors.New("scope must be one of this, following or all")
+	ErrStartDateInPast    = errors.New("startDate must be in the future")
+	ErrInvalidEndDate     = errors.New("endDate must be after startDate")
+	ErrInvalidTimezone    = errors.New("timezone must be a valid IANA time zone")
 )
 
 type PartyService interface {
 	Find(id uint64) (domain.Party, error)
 	FindById(id uint64) (domain.Party, error)
-	FindByCreatorId(creatorId uint64, page, limit int32) (domain.Parties, error)
+	FindByCreatorId(creatorId uint64, filter domain.PartyFilter, page, limit int32) (domain.Parties, error)
 	FindPartiesByLikerId(likerId uint64, page, limit int32) (domain.Parties, error)
-	GetParties(page, limit int32) (domain.Parties, error)
+	GetParties(filter domain.PartyFilter, page, limit int32) (domain.Parties, error)
 	Save(party domain.Party) (domain.Party, error)
-	Update(party domain.Party) (domain.Party, error)
+	Update(party domain.Party, scope string) (domain.Party, error)
 	Delete(id uint64) error
 }
 
 type partyService struct {
-	partyRepo         repositories.PartyRepository
-	userService       UserService
-	cloudinaryService *filesystem.CloudinaryService
+	partyRepo    repositories.PartyRepository
+	tierRepo     repositories.TicketTierRepository
+	imageRepo    repositories.PartyImageRepository
+	uploadRepo   repositories.UploadRepository
+	userService  UserService
+	imageStorage filesystem.ImageStorage
 }
 
-func NewPartyService(partyRepo repositories.PartyRepository, cloudinaryService *filesystem.CloudinaryService, userServ UserService) PartyService {
+func NewPartyService(partyRepo repositories.PartyRepository, tierRepo repositories.TicketTierRepository, imageRepo repositories.PartyImageRepository, uploadRepo repositories.UploadRepository, imageStorage filesystem.ImageStorage, userServ UserService) PartyService {
 	return partyService{
-		partyRepo:         partyRepo,
-		userService:       userServ,
-		cloudinaryService: cloudinaryService,
+		partyRepo:    partyRepo,
+		tierRepo:     tierRepo,
+		imageRepo:    imageRepo,
+		uploadRepo:   uploadRepo,
+		userService:  userServ,
+		imageStorage: imageStorage,
 	}
 }
 
@@ -47,11 +63,21 @@ func (p partyService) FindById(id uint64) (domain.Party, error) {
 	if err != nil {
 		return domain.Party{}, err
 	}
+
+	party.Tiers, err = p.tierRepo.FindByPartyId(id)
+	if err != nil {
+		return domain.Party{}, err
+	}
+
+	party.Images, err = p.imageRepo.FindByPartyId(id, domain.PartyImageKindHost)
+	if err != nil {
+		return domain.Party{}, err
+	}
 	return party, nil
 }
 
-func (p partyService) FindByCreatorId(creatorId uint64, page, limit int32) (domain.Parties, error) {
-	parties, err := p.partyRepo.FindByCreatorId(creatorId, page, limit)
+func (p partyService) FindByCreatorId(creatorId uint64, filter domain.PartyFilter, page, limit int32) (domain.Parties, error) {
+	parties, err := p.partyRepo.FindByCreatorId(creatorId, filter, page, limit)
 	if err != nil {
 		return domain.Parties{}, err
 	}
@@ -66,8 +92,8 @@ func (p partyService) FindPartiesByLikerId(likerId uint64, page, limit int32) (d
 	return parties, nil
 }
 
-func (p partyService) GetParties(page, limit int32) (domain.Parties, error) {
-	parties, err := p.partyRepo.GetParties(page, limit)
+func (p partyService) GetParties(filter domain.PartyFilter, page, limit int32) (domain.Parties, error) {
+	parties, err := p.partyRepo.GetParties(filter, page, limit)
 	if err != nil {
 		return domain.Parties{}, err
 	}
@@ -80,93 +106,219 @@ func (p partyService) Save(party domain.Party) (domain.Party, error) {
 		log.Printf("Party service Save.UserFineById: %s", err)
 		return domain.Party{}, err
 	}
+	if !user.Verified {
+		return domain.Party{}, ErrEmailNotVerified
+	}
+
+	party, err = validateSchedule(party)
+	if err != nil {
+		return domain.Party{}, err
+	}
+	if !party.StartDate.After(time.Now()) {
+		return domain.Party{}, ErrStartDateInPast
+	}
+
+	if party.HasTiers() {
+		party.Price = domain.LowestTierPrice(party.Tiers)
+	}
 
 	amountToSpend := party.Price
 	if amountToSpend < 10 {
 		amountToSpend = 10
 	}
 
-	if party.Image != "" {
-		imageFileName := "file_" + strconv.FormatInt(time.Now().UnixNano(), 32)
+	var occurrences []time.Time
+	if party.Recurrence != "" {
+		rule, err := domain.ParseRecurrenceRule(party.Recurrence)
+		if err != nil {
+			return domain.Party{}, err
+		}
+		occurrences = rule.Occurrences(party.StartDate.In(party.Location()))
+		if len(occurrences) == 0 {
+			return domain.Party{}, domain.ErrInvalidRecurrence
+		}
+		party.Recurrence = rule.String()
+
+		amountToSpend *= int32(len(occurrences))
+	}
 
-		imageUrl, err := p.cloudinaryService.SaveImageToCloudinary(party.Image, imageFileName)
+	party.Image, party.ImageMedium, party.ImageThumbnail = "", "", ""
+	if party.ImageUploadId != uuid.Nil {
+		upload, err := takeImageUpload(p.uploadRepo, party.ImageUploadId, party.CreatorId)
 		if err != nil {
-			log.Printf("Party service Update.SaveImageToCloud: %s", err)
 			return domain.Party{}, err
 		}
-		party.Image = imageUrl
+		party.Image, party.ImageMedium, party.ImageThumbnail = upload.Url, upload.MediumUrl, upload.ThumbnailUrl
 	}
 
-	createdParty, err := p.partyRepo.Save(party)
+	var createdParty domain.Party
+	if len(occurrences) > 0 {
+		createdParty, err = p.saveSeries(party, occurrences, amountToSpend)
+	} else {
+		createdParty, err = p.partyRepo.Save(party)
+	}
 	if err != nil {
 		log.Printf("Party service Save.RepoSave: %s", err)
+		deleteUnusedImage(p.partyRepo, p.imageStorage, party.Image, party.ImageMedium, party.ImageThumbnail)
 		return domain.Party{}, err
 	}
 
-	_, err = p.userService.UpdateUserBalance(user, amountToSpend*(-1))
-	if err != nil {
-		log.Printf("Party service Save.UpdateUserBalance: %s", err)
-		return domain.Party{}, err
+	if len(occurrences) == 0 {
+		_, err = p.userService.UpdateUserBalance(user, amountToSpend*(-1))
+		if err != nil {
+			log.Printf("Party service Save.UpdateUserBalance: %s", err)
+			return domain.Party{}, err
+		}
 	}
 
 	return createdParty, nil
 }
 
-func (p partyService) Update(party domain.Party) (domain.Party, error) {
-	partyFromDb, err := p.partyRepo.FindById(party.Id)
+func validateSchedule(party domain.Party) (domain.Party, error) {
+	if party.Timezone == "" {
+		party.Timezone = time.UTC.String()
+	}
+	_, err := time.LoadLocation(party.Timezone)
 	if err != nil {
-		log.Printf("Party service Update.FindByIdFromRepo: %s", err)
-		return domain.Party{}, err
+		return domain.Party{}, ErrInvalidTimezone
+	}
+	if !party.EndDate.After(party.StartDate) {
+		return domain.Party{}, ErrInvalidEndDate
 	}
+	return party, nil
+}
 
-	if party.Image == "" {
-		party.Image = partyFromDb.Image
+func (p partyService) saveSeries(party domain.Party, occurrences []time.Time, cost int32) (domain.Party, error) {
+	parties := make([]domain.Party, len(occurrences))
+	for i, startDate := range occurrences {
+		parties[i] = party
+		parties[i].StartDate = startDate
+		parties[i].EndDate = startDate.Add(party.Duration())
 	}
 
-	imageExists := partyFromDb.Image == party.Image
+	series, err := p.partyRepo.SaveSeries(domain.PartySeries{
+		CreatorId: party.CreatorId,
+		Rule:      party.Recurrence,
+	}, parties, cost)
+	if err != nil {
+		return domain.Party{}, err
+	}
+	return series.Parties[0], nil
+}
 
-	if !imageExists {
-		if partyFromDb.Image != "" {
-			err = p.cloudinaryService.DeleteImage(partyFromDb.Image)
-			if err != nil {
-				return domain.Party{}, err
-			}
-		}
+func (p partyService) Update(party domain.Party, scope string) (domain.Party, error) {
+	if scope == "" {
+		scope = domain.SeriesScopeThis
+	}
+	if scope != domain.SeriesScopeThis && scope != domain.SeriesScopeFollowing && scope != domain.SeriesScopeAll {
+		return domain.Party{}, ErrInvalidSeriesScope
+	}
 
-		imageFileName := "file_" + strconv.FormatInt(time.Now().UnixNano(), 32)
+	partyFromDb, err := p.FindById(party.Id)
+	if err != nil {
+		log.Printf("Party service Update.FindByIdFromRepo: %s", err)
+		return domain.Party{}, err
+	}
 
-		imageUrl, err := p.cloudinaryService.SaveImageToCloudinary(party.Image, imageFileName)
+	if party.Timezone == "" {
+		party.Timezone = partyFromDb.Timezone
+	}
+	party, err = validateSchedule(party)
+	if err != nil {
+		return domain.Party{}, err
+	}
+
+	party.Image, party.ImageMedium, party.ImageThumbnail = partyFromDb.Image, partyFromDb.ImageMedium, partyFromDb.ImageThumbnail
+	imageChanged := party.ImageUploadId != uuid.Nil
+	if imageChanged {
+		upload, err := takeImageUpload(p.uploadRepo, party.ImageUploadId, partyFromDb.CreatorId)
 		if err != nil {
-			log.Printf("Party service Update.SaveImageToCloud: %s", err)
 			return domain.Party{}, err
 		}
-		party.Image = imageUrl
+		party.Image, party.ImageMedium, party.ImageThumbnail = upload.Url, upload.MediumUrl, upload.ThumbnailUrl
 	}
 
-	updatedParty, err := p.partyRepo.Update(party)
+	var updatedParty domain.Party
+	if scope == domain.SeriesScopeThis || partyFromDb.SeriesId == nil {
+		updatedParty, err = p.partyRepo.Update(party)
+	} else {
+		updatedParty, err = p.updateSeries(party, partyFromDb, scope)
+	}
 	if err != nil {
 		log.Printf("Party service Update.RepoUpdate: %s", err)
+		if imageChanged {
+			deleteUnusedImage(p.partyRepo, p.imageStorage, party.Image, party.ImageMedium, party.ImageThumbnail)
+		}
 		return domain.Party{}, err
 	}
+	updatedParty.Tiers = partyFromDb.Tiers
+
+	if imageChanged {
+		deleteUnusedImage(p.partyRepo, p.imageStorage, partyFromDb.Image, partyFromDb.ImageMedium, partyFromDb.ImageThumbnail)
+	}
 	return updatedParty, nil
 }
 
+func (p partyService) updateSeries(party domain.Party, partyFromDb domain.Party, scope string) (domain.Party, error) {
+	party.SeriesId = partyFromDb.SeriesId
+
+	var from *time.Time
+	if scope == domain.SeriesScopeFollowing {
+		from = &partyFromDb.StartDate
+	}
+
+	parties, err := p.partyRepo.UpdateSeries(party, party.StartDate.Sub(partyFromDb.StartDate), from)
+	if err != nil {
+		return domain.Party{}, err
+	}
+	for _, updatedParty := range parties {
+		if updatedParty.Id == party.Id {
+			return updatedParty, nil
+		}
+	}
+	return domain.Party{}, sql.ErrNoRows
+}
+
 func (p partyService) Delete(id uint64) error {
 	deletedParty, err := p.FindById(id)
 	if err != nil {
 		return err
 	}
 
-	if deletedParty.Image != "" {
-		err = p.cloudinaryService.DeleteImage(deletedParty.Image)
-		if err != nil {
-			return err
-		}
-	}
-
 	err = p.partyRepo.Delete(id)
 	if err != nil {
 		return err
 	}
+
+	deleteUnusedImage(p.partyRepo, p.imageStorage, deletedParty.Image, deletedParty.ImageMedium, deletedParty.ImageThumbnail)
 	return nil
 }
+
+func deleteUnusedImage(partyRepo repositories.PartyRepository, imageStorage filesystem.ImageStorage, image string, variants ...string) {
+	if image == "" {
+		return
+	}
+
+	count, err := partyRepo.CountByImage(image)
+	if err != nil {
+		log.Printf("Party service deleteUnusedImage.CountByImage: %s", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	err = imageStorage.Delete(image)
+	if err != nil {
+		log.Printf("Party service deleteUnusedImage.DeleteImage: %s", err)
+	}
+	for _, variant := range variants {
+		if variant == "" || variant == image {
+			continue
+		}
+		err = imageStorage.Delete(variant)
+		if err != nil {
+			log.Printf("Party service deleteUnusedImage.DeleteVariant: %s", err)
+		}
+	}
+}
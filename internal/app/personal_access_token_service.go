@@ -0,0 +1,97 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"log"
+	"strings"
+)
+
+const (
+	PersonalAccessTokenPrefix = "pat_"
+	personalAccessTokenSize   = 32
+	personalAccessTokenShown  = 8
+)
+
+type PersonalAccessTokenService interface {
+	Find(id uint64) (domain.PersonalAccessToken, error)
+	FindByUserId(userId uint64) ([]domain.PersonalAccessToken, error)
+	Save(token domain.PersonalAccessToken) (domain.PersonalAccessToken, error)
+	Delete(id uint64) error
+	Authenticate(plainToken string) (domain.PersonalAccessToken, error)
+}
+
+type personalAccessTokenService struct {
+	tokenRepo repositories.PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenService(tr repositories.PersonalAccessTokenRepository) PersonalAccessTokenService {
+	return personalAccessTokenService{
+		tokenRepo: tr,
+	}
+}
+
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+func (s personalAccessTokenService) Find(id uint64) (domain.PersonalAccessToken, error) {
+	token, err := s.tokenRepo.FindById(id)
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	return token, nil
+}
+
+func (s personalAccessTokenService) FindByUserId(userId uint64) ([]domain.PersonalAccessToken, error) {
+	tokens, err := s.tokenRepo.FindByUserId(userId)
+	if err != nil {
+		return []domain.PersonalAccessToken{}, err
+	}
+	return tokens, nil
+}
+
+func (s personalAccessTokenService) Save(token domain.PersonalAccessToken) (domain.PersonalAccessToken, error) {
+	secret, err := generateRandomToken(personalAccessTokenSize)
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	plainToken := PersonalAccessTokenPrefix + secret
+
+	token.TokenHash = hashToken(plainToken)
+	token.Prefix = plainToken[:len(PersonalAccessTokenPrefix)+personalAccessTokenShown]
+
+	token, err = s.tokenRepo.Save(token)
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	token.Token = plainToken
+
+	return token, nil
+}
+
+func (s personalAccessTokenService) Delete(id uint64) error {
+	return s.tokenRepo.Delete(id)
+}
+
+func (s personalAccessTokenService) Authenticate(plainToken string) (domain.PersonalAccessToken, error) {
+	token, err := s.tokenRepo.FindByHash(hashToken(plainToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PersonalAccessToken{}, ErrInvalidToken
+		}
+		return domain.PersonalAccessToken{}, err
+	}
+	if token.IsExpired() {
+		return domain.PersonalAccessToken{}, ErrInvalidToken
+	}
+
+	err = s.tokenRepo.Touch(token.Id)
+	if err != nil {
+		log.Printf("PersonalAccessTokenService: failed to touch token %s", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"strings"
+)
+
+var (
+	ErrPromoCodeNotFound  = errors.New("promo code not found")
+	ErrInvalidDiscount    = errors.New("percent discount must not exceed 100")
+	ErrInvalidValidPeriod = errors.New("validUntil must be after validFrom")
+)
+
+type PromoCodeService interface {
+	FindByPartyId(partyId uint64) ([]domain.PromoCode, error)
+	FindRedemptions(partyId, promoCodeId uint64) ([]domain.PromoRedemption, error)
+	Save(promoCode domain.PromoCode) (domain.PromoCode, error)
+	Delete(partyId, id uint64) error
+}
+
+type promoCodeService struct {
+	promoCodeRepo repositories.PromoCodeRepository
+}
+
+func NewPromoCodeService(pr repositories.PromoCodeRepository) PromoCodeService {
+	return promoCodeService{
+		promoCodeRepo: pr,
+	}
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (s promoCodeService) FindByPartyId(partyId uint64) ([]domain.PromoCode, error) {
+	promoCodes, err := s.promoCodeRepo.FindByPartyId(partyId)
+	if err != nil {
+		return []domain.PromoCode{}, err
+	}
+	return promoCodes, nil
+}
+
+func (s promoCodeService) FindRedemptions(partyId, promoCodeId uint64) ([]domain.PromoRedemption, error) {
+	redemptions, err := s.promoCodeRepo.FindRedemptions(partyId, promoCodeId)
+	if err != nil {
+		return []domain.PromoRedemption{}, err
+	}
+	return redemptions, nil
+}
+
+func (s promoCodeService) Save(promoCode domain.PromoCode) (domain.PromoCode, error) {
+	if promoCode.DiscountType == domain.DiscountPercent && promoCode.DiscountValue > 100 {
+		return domain.PromoCode{}, ErrInvalidDiscount
+	}
+	if promoCode.ValidFrom != nil && promoCode.ValidUntil != nil && !promoCode.ValidUntil.After(*promoCode.ValidFrom) {
+		return domain.PromoCode{}, ErrInvalidValidPeriod
+	}
+	promoCode.Code = normalizePromoCode(promoCode.Code)
+
+	promoCode, err := s.promoCodeRepo.Save(promoCode)
+	if err != nil {
+		return domain.PromoCode{}, err
+	}
+	return promoCode, nil
+}
+
+func (s promoCodeService) Delete(partyId, id uint64) error {
+	return s.promoCodeRepo.Delete(partyId, id)
+}
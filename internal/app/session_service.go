@@ -3,106 +3,556 @@ package app
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/google/uuid"
+	"go-rest-api/config"
 	"go-rest-api/internal/domain"
 	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/mail"
+	"go-rest-api/internal/infra/tokens"
 	"golang.org/x/crypto/bcrypt"
 	"log"
 	"time"
 )
 
+const (
+	passwordResetTokenTTL     = time.Hour
+	emailVerificationTokenTTL = 24 * time.Hour
+	sessionTouchInterval      = time.Minute
+	twoFactorChallengeTTL     = 5 * time.Minute
+	twoFactorChallengePurpose = "2fa"
+)
+
+var (
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrEmailTaken         = errors.New("email is already taken")
+	ErrUserBanned         = errors.New("user is banned")
+)
+
 type SessionService interface {
-	Register(user domain.User) (domain.User, string, error)
-	Login(user domain.User) (domain.User, string, error)
+	Register(user domain.User, client domain.ClientInfo) (domain.User, domain.AuthTokens, error)
+	Login(user domain.User, client domain.ClientInfo) (domain.User, domain.AuthTokens, error)
+	LoginExternal(user domain.User, client domain.ClientInfo) (domain.User, domain.AuthTokens, error)
+	VerifyTwoFactor(verification domain.TwoFactorVerification, client domain.ClientInfo) (domain.User, domain.AuthTokens, error)
+	Refresh(refreshToken string) (domain.User, domain.AuthTokens, error)
 	Logout(sess domain.Session) error
-	Check(sess domain.Session) error
-	GenerateToken(user domain.User) (string, error)
+	Check(sess domain.Session, client domain.ClientInfo) (domain.Session, error)
+	GenerateToken(user domain.User, client domain.ClientInfo) (domain.AuthTokens, error)
+	FindSessions(userId uint64) ([]domain.Session, error)
+	RevokeSession(sess domain.Session) error
+	RevokeOtherSessions(sess domain.Session) error
+	ForgotPassword(email string) error
+	ResetPassword(reset domain.PasswordReset) error
+	SendVerificationEmail(user domain.User) error
+	VerifyEmail(token string) error
+	ChangePassword(sess domain.Session, change domain.PasswordChange) error
+	RequestEmailChange(user domain.User, change domain.EmailChange) error
+	ConfirmEmailChange(token string) error
 }
 
 type sessionService struct {
-	userServ    UserService
-	sessionRepo repositories.SessionRepository
-	tokenAuth   *jwtauth.JWTAuth
+	userServ         UserService
+	twoFactorServ    TwoFactorService
+	loginGuard       LoginGuard
+	sessionRepo      repositories.SessionRepository
+	userTokenRepo    repositories.UserTokenRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	mailer           mail.Mailer
+	tokenAuth        *tokens.JWTAuth
+	dummyHash        string
+	frontendUrl      string
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
 }
 
-func NewSessionService(sr repositories.SessionRepository, us UserService, tokenAuth *jwtauth.JWTAuth) SessionService {
+func NewSessionService(sr repositories.SessionRepository, us UserService, tfs TwoFactorService, lg LoginGuard, utr repositories.UserTokenRepository, rtr repositories.RefreshTokenRepository, mailer mail.Mailer, tokenAuth *tokens.JWTAuth, cfg config.Configuration) SessionService {
+	// Compared against for unknown emails so the response time doesn't reveal accounts.
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte(uuid.NewString()), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("SessionService: failed to generate dummy hash %s", err)
+	}
+
 	return &sessionService{
-		sessionRepo: sr,
-		userServ:    us,
-		tokenAuth:   tokenAuth,
+		sessionRepo:      sr,
+		userServ:         us,
+		twoFactorServ:    tfs,
+		loginGuard:       lg,
+		userTokenRepo:    utr,
+		refreshTokenRepo: rtr,
+		mailer:           mailer,
+		tokenAuth:        tokenAuth,
+		dummyHash:        string(dummyHash),
+		frontendUrl:      cfg.FrontendUrl,
+		accessTokenTTL:   cfg.AccessTokenTTL,
+		refreshTokenTTL:  cfg.RefreshTokenTTL,
 	}
 }
 
-func (s sessionService) Register(user domain.User) (domain.User, string, error) {
+func (s sessionService) Register(user domain.User, client domain.ClientInfo) (domain.User, domain.AuthTokens, error) {
 	_, err := s.userServ.FindByEmail(user.Email)
 	if err == nil {
-		return domain.User{}, "", errors.New("invalid credentials")
+		return domain.User{}, domain.AuthTokens{}, errors.New("invalid credentials")
 	} else if !errors.Is(err, sql.ErrNoRows) {
-		return domain.User{}, "", err
+		return domain.User{}, domain.AuthTokens{}, err
 	}
 
 	user, err = s.userServ.Save(user)
 	if err != nil {
-		return domain.User{}, "", err
+		return domain.User{}, domain.AuthTokens{}, err
 	}
 
-	token, err := s.GenerateToken(user)
+	err = s.SendVerificationEmail(user)
 	if err != nil {
-		return domain.User{}, "", err
+		log.Printf("AuthService: failed to send verification email %s", err)
 	}
 
-	return user, token, nil
+	authTokens, err := s.GenerateToken(user, client)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
+	return user, authTokens, nil
 }
 
-func (s sessionService) Login(user domain.User) (domain.User, string, error) {
+func (s sessionService) Login(user domain.User, client domain.ClientInfo) (domain.User, domain.AuthTokens, error) {
+	err := s.loginGuard.Check(user.Email, client)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
 	u, err := s.userServ.FindByEmail(user.Email)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			log.Printf("AuthService: failed to find user %s", err)
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("AuthService: login error %s", err)
+			return domain.User{}, domain.AuthTokens{}, err
 		}
-		log.Printf("AuthService: login error %s", err)
-		return domain.User{}, "", err
+		s.checkPasswordHash(user.Password, s.dummyHash)
+		s.loginGuard.RecordFailure(user.Email, nil, client, domain.LoginFailureUnknownEmail)
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidCredentials
 	}
 	valid := s.checkPasswordHash(user.Password, u.Password)
 	if !valid {
-		return domain.User{}, "", errors.New("invalid credentials")
+		s.loginGuard.RecordFailure(u.Email, &u.Id, client, domain.LoginFailureInvalidPassword)
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidCredentials
+	}
+
+	return s.finishLogin(u, client, true)
+}
+
+func (s sessionService) LoginExternal(user domain.User, client domain.ClientInfo) (domain.User, domain.AuthTokens, error) {
+	return s.finishLogin(user, client, false)
+}
+
+func (s sessionService) finishLogin(user domain.User, client domain.ClientInfo, withPassword bool) (domain.User, domain.AuthTokens, error) {
+	if user.IsBanned() {
+		return domain.User{}, domain.AuthTokens{}, ErrUserBanned
+	}
+
+	if user.TotpEnabled {
+		challengeToken, err := s.issueTwoFactorChallenge(user, withPassword)
+		if err != nil {
+			return domain.User{}, domain.AuthTokens{}, err
+		}
+		return user, domain.AuthTokens{ChallengeToken: challengeToken}, nil
+	}
+
+	authTokens, err := s.GenerateToken(user, client)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+	if withPassword {
+		s.loginGuard.RecordSuccess(user.Email, user.Id, client)
 	}
 
-	token, err := s.GenerateToken(u)
+	return user, authTokens, nil
+}
+
+func (s sessionService) VerifyTwoFactor(verification domain.TwoFactorVerification, client domain.ClientInfo) (domain.User, domain.AuthTokens, error) {
+	token, err := s.tokenAuth.Decode(verification.ChallengeToken)
 	if err != nil {
-		return domain.User{}, "", err
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
 	}
 
-	return u, token, err
+	claims := token.PrivateClaims()
+	purpose, _ := claims["purpose"].(string)
+	userId, ok := claims["user_id"].(float64)
+	if purpose != twoFactorChallengePurpose || !ok {
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+	}
+
+	user, err := s.userServ.FindById(uint64(userId))
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
+	if user.IsBanned() {
+		return domain.User{}, domain.AuthTokens{}, ErrUserBanned
+	}
+
+	err = s.loginGuard.Check(user.Email, client)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
+	err = s.twoFactorServ.Verify(user, verification.Code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			s.loginGuard.RecordFailure(user.Email, &user.Id, client, domain.LoginFailureInvalidCode)
+		}
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
+	authTokens, err := s.GenerateToken(user, client)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+	if withPassword, _ := claims["password"].(bool); withPassword {
+		s.loginGuard.RecordSuccess(user.Email, user.Id, client)
+	}
+
+	return user, authTokens, nil
+}
+
+func (s sessionService) issueTwoFactorChallenge(user domain.User, withPassword bool) (string, error) {
+	claims := map[string]interface{}{
+		"user_id":  user.Id,
+		"purpose":  twoFactorChallengePurpose,
+		"password": withPassword,
+	}
+	jwtauth.SetExpiry(claims, time.Now().Add(twoFactorChallengeTTL))
+
+	_, challengeToken, err := s.tokenAuth.Encode(claims)
+	if err != nil {
+		return "", err
+	}
+	return challengeToken, nil
+}
+
+// A reused refresh token means it was stolen, so the whole session is revoked.
+func (s sessionService) Refresh(refreshToken string) (domain.User, domain.AuthTokens, error) {
+	token, err := s.refreshTokenRepo.FindByHash(hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+		}
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
+	if token.UsedDate != nil {
+		log.Printf("AuthService: refresh token reuse detected for user %d, revoking session", token.UserId)
+		err = s.sessionRepo.Delete(token.Session())
+		if err != nil {
+			return domain.User{}, domain.AuthTokens{}, err
+		}
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+	}
+	if token.IsExpired() {
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+	}
+
+	err = s.refreshTokenRepo.MarkUsed(token.Id)
+	if err != nil {
+		err = s.sessionRepo.Delete(token.Session())
+		if err != nil {
+			return domain.User{}, domain.AuthTokens{}, err
+		}
+		return domain.User{}, domain.AuthTokens{}, ErrInvalidToken
+	}
+
+	user, err := s.userServ.FindById(token.UserId)
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
+	authTokens, err := s.issueTokens(token.Session())
+	if err != nil {
+		return domain.User{}, domain.AuthTokens{}, err
+	}
+
+	return user, authTokens, nil
 }
 
 func (s sessionService) Logout(session domain.Session) error {
 	return s.sessionRepo.Delete(session)
 }
 
-func (s sessionService) Check(session domain.Session) error {
-	return s.sessionRepo.Exists(session)
+func (s sessionService) Check(session domain.Session, client domain.ClientInfo) (domain.Session, error) {
+	sess, err := s.sessionRepo.Find(session)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Session{}, errors.New("session does not exist")
+		}
+		return domain.Session{}, err
+	}
+
+	if time.Since(sess.LastSeenDate) > sessionTouchInterval || sess.UserAgent != client.UserAgent || sess.Ip != client.Ip {
+		sess.UserAgent = client.UserAgent
+		sess.Ip = client.Ip
+		err = s.sessionRepo.Touch(sess)
+		if err != nil {
+			log.Printf("AuthService: failed to touch session %s", err)
+		}
+	}
+
+	return sess, nil
+}
+
+func (s sessionService) FindSessions(userId uint64) ([]domain.Session, error) {
+	return s.sessionRepo.FindByUserId(userId)
 }
 
-func (s sessionService) GenerateToken(user domain.User) (string, error) {
-	sess := domain.Session{UserId: user.Id, UUID: uuid.New()}
-	err := s.sessionRepo.Save(sess)
+func (s sessionService) RevokeSession(sess domain.Session) error {
+	_, err := s.sessionRepo.Find(sess)
 	if err != nil {
-		return "", err
+		return err
+	}
+	return s.sessionRepo.Delete(sess)
+}
+
+func (s sessionService) RevokeOtherSessions(sess domain.Session) error {
+	return s.sessionRepo.DeleteOthers(sess)
+}
+
+func (s sessionService) GenerateToken(user domain.User, client domain.ClientInfo) (domain.AuthTokens, error) {
+	sess, err := s.sessionRepo.Save(domain.Session{
+		UserId:    user.Id,
+		UUID:      uuid.New(),
+		UserAgent: client.UserAgent,
+		Ip:        client.Ip,
+	})
+	if err != nil {
+		return domain.AuthTokens{}, err
 	}
 
+	return s.issueTokens(sess)
+}
+
+func (s sessionService) issueTokens(sess domain.Session) (domain.AuthTokens, error) {
+	expiresAt := time.Now().Add(s.accessTokenTTL)
 	claims := map[string]interface{}{
 		"user_id": sess.UserId,
 		"uuid":    sess.UUID,
 	}
-	jwtauth.SetExpiry(claims, time.Now().Add(72*time.Hour))
+	jwtauth.SetExpiry(claims, expiresAt)
+
+	_, accessToken, err := s.tokenAuth.Encode(claims)
+	if err != nil {
+		return domain.AuthTokens{}, err
+	}
 
-	_, tokenString, err := s.tokenAuth.Encode(claims)
+	refreshToken, err := generateRandomToken(32)
+	if err != nil {
+		return domain.AuthTokens{}, err
+	}
+
+	_, err = s.refreshTokenRepo.Save(domain.RefreshToken{
+		UserId:      sess.UserId,
+		SessionUUID: sess.UUID,
+		TokenHash:   hashToken(refreshToken),
+		ExpiresDate: time.Now().Add(s.refreshTokenTTL),
+	})
+	if err != nil {
+		return domain.AuthTokens{}, err
+	}
+
+	return domain.AuthTokens{
+		AccessToken:     accessToken,
+		RefreshToken:    refreshToken,
+		AccessExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s sessionService) ForgotPassword(email string) error {
+	user, err := s.userServ.FindByEmail(email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.issueUserToken(user, domain.PasswordResetPurpose, "", passwordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nUse the link below to reset your password, it is valid for one hour:\n%s/reset-password?token=%s\n\nIf you didn't request a password reset, you can ignore this email.",
+		user.Name,
+		s.frontendUrl,
+		token,
+	)
+	return s.mailer.Send(user.Email, "Reset your password", body)
+}
+
+func (s sessionService) ResetPassword(reset domain.PasswordReset) error {
+	userToken, err := s.consumeUserToken(domain.PasswordResetPurpose, reset.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userServ.FindById(userToken.UserId)
+	if err != nil {
+		return err
+	}
+
+	err = s.userServ.UpdatePassword(user, reset.Password)
+	if err != nil {
+		return err
+	}
+
+	return s.sessionRepo.DeleteByUserId(user.Id)
+}
+
+func (s sessionService) SendVerificationEmail(user domain.User) error {
+	if user.Verified {
+		return errors.New("email is already verified")
+	}
+
+	token, err := s.issueUserToken(user, domain.EmailVerificationPurpose, "", emailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nPlease confirm your email address by opening the link below, it is valid for 24 hours:\n%s/verify-email?token=%s",
+		user.Name,
+		s.frontendUrl,
+		token,
+	)
+	return s.mailer.Send(user.Email, "Confirm your email", body)
+}
+
+func (s sessionService) VerifyEmail(token string) error {
+	userToken, err := s.consumeUserToken(domain.EmailVerificationPurpose, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userServ.FindById(userToken.UserId)
+	if err != nil {
+		return err
+	}
+
+	return s.userServ.MarkVerified(user)
+}
+
+func (s sessionService) ChangePassword(sess domain.Session, change domain.PasswordChange) error {
+	user, err := s.userServ.FindById(sess.UserId)
+	if err != nil {
+		return err
+	}
+	if !s.checkPasswordHash(change.CurrentPassword, user.Password) {
+		return ErrInvalidCredentials
+	}
+
+	err = s.userServ.UpdatePassword(user, change.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.sessionRepo.DeleteOthers(sess)
+}
+
+func (s sessionService) RequestEmailChange(user domain.User, change domain.EmailChange) error {
+	if !s.checkPasswordHash(change.Password, user.Password) {
+		return ErrInvalidCredentials
+	}
+
+	err := s.checkEmailAvailable(change.Email)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issueUserToken(user, domain.EmailChangePurpose, change.Email, emailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nPlease confirm your new email address by opening the link below, it is valid for 24 hours:\n%s/confirm-email-change?token=%s",
+		user.Name,
+		s.frontendUrl,
+		token,
+	)
+	return s.mailer.Send(change.Email, "Confirm your new email", body)
+}
+
+func (s sessionService) ConfirmEmailChange(token string) error {
+	userToken, err := s.consumeUserToken(domain.EmailChangePurpose, token)
+	if err != nil {
+		return err
+	}
+
+	err = s.checkEmailAvailable(userToken.Payload)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userServ.FindById(userToken.UserId)
+	if err != nil {
+		return err
+	}
+
+	return s.userServ.UpdateEmail(user, userToken.Payload)
+}
+
+func (s sessionService) checkEmailAvailable(email string) error {
+	_, err := s.userServ.FindByEmail(email)
+	if err == nil {
+		return ErrEmailTaken
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+func (s sessionService) issueUserToken(user domain.User, purpose, payload string, ttl time.Duration) (string, error) {
+	err := s.userTokenRepo.DeleteByUserId(user.Id, purpose)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := generateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.userTokenRepo.Save(domain.UserToken{
+		UserId:      user.Id,
+		Purpose:     purpose,
+		TokenHash:   hashToken(token),
+		Payload:     payload,
+		ExpiresDate: time.Now().Add(ttl),
+	})
 	if err != nil {
 		return "", err
 	}
-	return tokenString, nil
+
+	return token, nil
+}
+
+func (s sessionService) consumeUserToken(purpose, token string) (domain.UserToken, error) {
+	userToken, err := s.userTokenRepo.FindByHash(purpose, hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.UserToken{}, ErrInvalidToken
+		}
+		return domain.UserToken{}, err
+	}
+	if userToken.UsedDate != nil || userToken.IsExpired() {
+		return domain.UserToken{}, ErrInvalidToken
+	}
+
+	err = s.userTokenRepo.MarkUsed(userToken.Id)
+	if err != nil {
+		return domain.UserToken{}, ErrInvalidToken
+	}
+
+	return userToken, nil
 }
 
 func (s sessionService) checkPasswordHash(password, hash string) bool {
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type memorySessionRepo struct {
+	repositories.SessionRepository
+	sessions *[]domain.Session
+}
+
+func (r memorySessionRepo) Find(sess domain.Session) (domain.Session, error) {
+	for _, s := range *r.sessions {
+		if s.UUID == sess.UUID && s.UserId == sess.UserId {
+			return s, nil
+		}
+	}
+	return domain.Session{}, sql.ErrNoRows
+}
+
+func (r memorySessionRepo) Delete(sess domain.Session) error {
+	sessions := (*r.sessions)[:0]
+	for _, s := range *r.sessions {
+		if s.UUID != sess.UUID || s.UserId != sess.UserId {
+			sessions = append(sessions, s)
+		}
+	}
+	*r.sessions = sessions
+	return nil
+}
+
+func TestRevokeSessionOnlyFindsTheUsersOwnSessions(t *testing.T) {
+	own := domain.Session{UserId: 7, UUID: uuid.New()}
+	other := domain.Session{UserId: 8, UUID: uuid.New()}
+	sessionRepo := memorySessionRepo{sessions: &[]domain.Session{own, other}}
+	service := sessionService{sessionRepo: sessionRepo}
+
+	for name, sess := range map[string]domain.Session{
+		"unknown session":        {UserId: 7, UUID: uuid.New()},
+		"another user's session": {UserId: 7, UUID: other.UUID},
+	} {
+		err := service.RevokeSession(sess)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("RevokeSession(%s) error = %v, want %v", name, err, sql.ErrNoRows)
+		}
+	}
+
+	err := service.RevokeSession(own)
+	if err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+	if len(*sessionRepo.sessions) != 1 || (*sessionRepo.sessions)[0] != other {
+		t.Fatalf("sessions = %+v, want only the other user's session", *sessionRepo.sessions)
+	}
+}
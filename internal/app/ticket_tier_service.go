@@ -0,0 +1,83 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+)
+
+var (
+	ErrTierRequired      = errors.New("this party has ticket tiers, choose one with tierId")
+	ErrTierNotFound      = errors.New("ticket tier not found")
+	ErrTierCapacityBelow = errors.New("capacity cannot be lower than the tickets already sold")
+	ErrTierHasMembers    = errors.New("ticket tier already has members")
+)
+
+type TicketTierService interface {
+	Save(tier domain.TicketTier) (domain.TicketTier, error)
+	Update(tier domain.TicketTier) (domain.TicketTier, error)
+	Delete(partyId, id uint64) error
+}
+
+type ticketTierService struct {
+	tierRepo repositories.TicketTierRepository
+}
+
+func NewTicketTierService(tr repositories.TicketTierRepository) TicketTierService {
+	return ticketTierService{
+		tierRepo: tr,
+	}
+}
+
+func (s ticketTierService) Save(tier domain.TicketTier) (domain.TicketTier, error) {
+	tier, err := s.tierRepo.Save(tier)
+	if err != nil {
+		return domain.TicketTier{}, err
+	}
+	return tier, nil
+}
+
+func (s ticketTierService) Update(tier domain.TicketTier) (domain.TicketTier, error) {
+	tierFromDb, err := s.tierRepo.FindById(tier.PartyId, tier.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TicketTier{}, ErrTierNotFound
+		}
+		return domain.TicketTier{}, err
+	}
+	if tier.Capacity < tierFromDb.Sold {
+		return domain.TicketTier{}, ErrTierCapacityBelow
+	}
+
+	tier, err = s.tierRepo.Update(tier)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TicketTier{}, ErrTierCapacityBelow
+		}
+		return domain.TicketTier{}, err
+	}
+	return tier, nil
+}
+
+func (s ticketTierService) Delete(partyId, id uint64) error {
+	tier, err := s.tierRepo.FindById(partyId, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTierNotFound
+		}
+		return err
+	}
+	if tier.Sold > 0 {
+		return ErrTierHasMembers
+	}
+
+	err = s.tierRepo.Delete(partyId, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTierHasMembers
+		}
+		return err
+	}
+	return nil
+}
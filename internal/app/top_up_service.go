@@ -0,0 +1,89 @@
+package app
+
+import (
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/payments"
+	"log"
+)
+
+var ErrPaymentsUnavailable = errors.New("payments are not available")
+
+type TopUpService interface {
+	Find(id uint64) (domain.TopUp, error)
+	Save(topUp domain.TopUp) (domain.TopUp, error)
+	HandleWebhook(payload []byte, signature string) error
+}
+
+type topUpService struct {
+	topUpRepo repositories.TopUpRepository
+	provider  payments.Provider
+}
+
+func NewTopUpService(tr repositories.TopUpRepository, provider payments.Provider) TopUpService {
+	return topUpService{
+		topUpRepo: tr,
+		provider:  provider,
+	}
+}
+
+func (s topUpService) Find(id uint64) (domain.TopUp, error) {
+	topUp, err := s.topUpRepo.FindById(id)
+	if err != nil {
+		return domain.TopUp{}, err
+	}
+	return topUp, nil
+}
+
+func (s topUpService) Save(topUp domain.TopUp) (domain.TopUp, error) {
+	if s.provider == nil {
+		return domain.TopUp{}, ErrPaymentsUnavailable
+	}
+
+	session, err := s.provider.CreateCheckout(topUp)
+	if err != nil {
+		return domain.TopUp{}, err
+	}
+
+	topUp.Provider = s.provider.Name()
+	topUp.ProviderSessionId = session.Id
+	topUp.CheckoutUrl = session.Url
+
+	topUp, err = s.topUpRepo.Save(topUp)
+	if err != nil {
+		return domain.TopUp{}, err
+	}
+	return topUp, nil
+}
+
+func (s topUpService) HandleWebhook(payload []byte, signature string) error {
+	if s.provider == nil {
+		return ErrPaymentsUnavailable
+	}
+
+	event, err := s.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	var topUp domain.TopUp
+	switch event.Type {
+	case domain.PaymentEventCheckoutCompleted:
+		topUp, err = s.topUpRepo.Complete(s.provider.Name(), event)
+	case domain.PaymentEventCheckoutFailed:
+		topUp, err = s.topUpRepo.Fail(s.provider.Name(), event)
+	default:
+		return nil
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentEventProcessed) {
+			log.Printf("TopUpService: skipping payment event %s", event.Id)
+			return nil
+		}
+		return err
+	}
+
+	log.Printf("TopUpService: top-up %d is %s", topUp.Id, topUp.Status)
+	return nil
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+)
+
+var (
+	ErrTransferToSelf     = errors.New("you cannot send points to yourself")
+	ErrRecipientNotFound  = errors.New("recipient not found")
+	ErrPartyNotFinished   = errors.New("party is not finished yet")
+	ErrNotPartyMember     = errors.New("only party members can tip the host")
+	ErrCannotTipOwnParty  = errors.New("you cannot tip your own party")
+	ErrZeroTransferAmount = errors.New("amount must be positive")
+)
+
+type TransferService interface {
+	Save(transfer domain.Transfer) (domain.Transfer, error)
+	Tip(party domain.Party, transfer domain.Transfer) (domain.Transfer, error)
+}
+
+type transferService struct {
+	transferRepo repositories.TransferRepository
+	userRepo     repositories.UserRepository
+	memberRepo   repositories.MemberRepository
+	dailyLimit   int32
+}
+
+func NewTransferService(tr repositories.TransferRepository, ur repositories.UserRepository, mr repositories.MemberRepository, dailyLimit int32) TransferService {
+	return transferService{
+		transferRepo: tr,
+		userRepo:     ur,
+		memberRepo:   mr,
+		dailyLimit:   dailyLimit,
+	}
+}
+
+func (s transferService) Save(transfer domain.Transfer) (domain.Transfer, error) {
+	if transfer.Amount <= 0 {
+		return domain.Transfer{}, ErrZeroTransferAmount
+	}
+	if transfer.SenderId == transfer.RecipientId {
+		return domain.Transfer{}, ErrTransferToSelf
+	}
+
+	sender, err := s.userRepo.FindById(transfer.SenderId)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	if !sender.Verified {
+		return domain.Transfer{}, ErrEmailNotVerified
+	}
+
+	recipient, err := s.userRepo.FindById(transfer.RecipientId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Transfer{}, ErrRecipientNotFound
+		}
+		return domain.Transfer{}, err
+	}
+	if recipient.IsBanned() {
+		return domain.Transfer{}, ErrRecipientNotFound
+	}
+
+	transfer, err = s.transferRepo.Save(transfer, s.dailyLimit)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	return transfer, nil
+}
+
+func (s transferService) Tip(party domain.Party, transfer domain.Transfer) (domain.Transfer, error) {
+	if party.CreatorId == transfer.SenderId {
+		return domain.Transfer{}, ErrCannotTipOwnParty
+	}
+	if !party.IsFinished() {
+		return domain.Transfer{}, ErrPartyNotFinished
+	}
+
+	err := s.memberRepo.Exists(domain.Member{PartyId: party.Id, UserId: transfer.SenderId})
+	if err != nil {
+		return domain.Transfer{}, ErrNotPartyMember
+	}
+
+	transfer.RecipientId = party.CreatorId
+	transfer.PartyId = &party.Id
+	return s.Save(transfer)
+}
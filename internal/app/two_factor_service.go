@@ -0,0 +1,149 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/tokens"
+	"strings"
+	"time"
+)
+
+const recoveryCodesCount = 10
+
+var (
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorDisabled    = errors.New("two-factor authentication is not enabled")
+)
+
+type TwoFactorService interface {
+	Enroll(user domain.User) (domain.TotpEnrollment, error)
+	Confirm(user domain.User, code string) ([]string, error)
+	Disable(user domain.User, code string) error
+	Verify(user domain.User, code string) error
+}
+
+type twoFactorService struct {
+	userRepo         repositories.UserRepository
+	recoveryCodeRepo repositories.RecoveryCodeRepository
+	issuer           string
+}
+
+func NewTwoFactorService(userRepo repositories.UserRepository, recoveryCodeRepo repositories.RecoveryCodeRepository, issuer string) TwoFactorService {
+	return twoFactorService{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		issuer:           issuer,
+	}
+}
+
+func (t twoFactorService) Enroll(user domain.User) (domain.TotpEnrollment, error) {
+	if user.TotpEnabled {
+		return domain.TotpEnrollment{}, ErrTwoFactorEnabled
+	}
+
+	secret, err := tokens.GenerateTotpSecret()
+	if err != nil {
+		return domain.TotpEnrollment{}, err
+	}
+
+	err = t.userRepo.UpdateTotp(user.Id, secret, false)
+	if err != nil {
+		return domain.TotpEnrollment{}, err
+	}
+
+	return domain.TotpEnrollment{
+		Secret: secret,
+		Uri:    tokens.TotpKeyUri(t.issuer, user.Email, secret),
+	}, nil
+}
+
+func (t twoFactorService) Confirm(user domain.User, code string) ([]string, error) {
+	if user.TotpEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+	if user.TotpSecret == "" {
+		return nil, ErrTwoFactorDisabled
+	}
+	err := t.useTotp(user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		code, err := generateRandomToken(5)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	err = t.recoveryCodeRepo.Replace(user.Id, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.userRepo.UpdateTotp(user.Id, user.TotpSecret, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (t twoFactorService) Disable(user domain.User, code string) error {
+	if !user.TotpEnabled {
+		return ErrTwoFactorDisabled
+	}
+
+	err := t.Verify(user, code)
+	if err != nil {
+		return err
+	}
+
+	err = t.recoveryCodeRepo.DeleteByUserId(user.Id)
+	if err != nil {
+		return err
+	}
+
+	return t.userRepo.UpdateTotp(user.Id, "", false)
+}
+
+func (t twoFactorService) Verify(user domain.User, code string) error {
+	if !user.TotpEnabled {
+		return ErrTwoFactorDisabled
+	}
+
+	err := t.useTotp(user, code)
+	if !errors.Is(err, ErrInvalidTwoFactorCode) {
+		return err
+	}
+
+	err = t.recoveryCodeRepo.Use(user.Id, hashRecoveryCode(code))
+	if err != nil {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (t twoFactorService) useTotp(user domain.User, code string) error {
+	step, ok := tokens.ValidateTotp(user.TotpSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	err := t.userRepo.UseTotpStep(user.Id, step)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidTwoFactorCode
+	}
+	return err
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return hashToken(normalized)
+}
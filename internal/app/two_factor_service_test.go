@@ -0,0 +1,70 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/tokens"
+	"testing"
+	"time"
+)
+
+type memoryTotpUserRepo struct {
+	repositories.UserRepository
+	lastStep *int64
+}
+
+func (r memoryTotpUserRepo) UseTotpStep(id uint64, step int64) error {
+	if step <= *r.lastStep {
+		return sql.ErrNoRows
+	}
+	*r.lastStep = step
+	return nil
+}
+
+type noRecoveryCodeRepo struct {
+	repositories.RecoveryCodeRepository
+}
+
+func (r noRecoveryCodeRepo) Use(userId uint64, codeHash string) error {
+	return sql.ErrNoRows
+}
+
+func TestTwoFactorVerifyRejectsReplayedCodes(t *testing.T) {
+	secret, err := tokens.GenerateTotpSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := domain.User{Id: 7, TotpSecret: secret, TotpEnabled: true}
+	service := NewTwoFactorService(memoryTotpUserRepo{lastStep: new(int64)}, noRecoveryCodeRepo{}, "test")
+
+	now := time.Now()
+	code, err := tokens.TotpCode(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous, err := tokens.TotpCode(secret, now.Add(-30*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := tokens.TotpCode(secret, now.Add(30*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = service.Verify(user, code)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	for name, code := range map[string]string{"the same code": code, "an older code": previous} {
+		err = service.Verify(user, code)
+		if !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Errorf("Verify(%s) error = %v, want %v", name, err, ErrInvalidTwoFactorCode)
+		}
+	}
+	err = service.Verify(user, next)
+	if err != nil {
+		t.Fatalf("Verify(next code) error = %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/filesystem"
+	"go-rest-api/internal/infra/imaging"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUploadNotFound = errors.New("upload not found")
+	ErrUploadTooLarge = errors.New("upload is too large")
+)
+
+type UploadService interface {
+	Save(upload domain.Upload, content io.Reader) (domain.Upload, error)
+}
+
+type uploadService struct {
+	uploadRepo   repositories.UploadRepository
+	imageStorage filesystem.ImageStorage
+	maxSize      int64
+	maxDimension int
+}
+
+func NewUploadService(uploadRepo repositories.UploadRepository, imageStorage filesystem.ImageStorage, maxSize int64, maxDimension int) UploadService {
+	return uploadService{
+		uploadRepo:   uploadRepo,
+		imageStorage: imageStorage,
+		maxSize:      maxSize,
+		maxDimension: maxDimension,
+	}
+}
+
+func (s uploadService) Save(upload domain.Upload, content io.Reader) (domain.Upload, error) {
+	limited := &sizeLimitReader{reader: content, remaining: s.maxSize}
+	data, err := io.ReadAll(limited)
+	if err != nil {
+		if limited.exceeded {
+			return domain.Upload{}, fmt.Errorf("%w, the limit is %d bytes", ErrUploadTooLarge, s.maxSize)
+		}
+		return domain.Upload{}, err
+	}
+
+	processed, err := imaging.Process(data, s.maxDimension)
+	if err != nil {
+		return domain.Upload{}, err
+	}
+
+	upload.Id = uuid.New()
+	upload.ContentType = processed.ContentType
+	upload.Size = int64(len(processed.Original))
+
+	fileName := newImageFileName()
+	var saved []string
+	save := func(suffix, extension string, content []byte) (string, error) {
+		if content == nil {
+			return upload.Url, nil
+		}
+		url, err := s.imageStorage.Save(fileName+suffix+extension, bytes.NewReader(content))
+		if err != nil {
+			return "", err
+		}
+		saved = append(saved, url)
+		return url, nil
+	}
+
+	upload.Url, err = save("", processed.Extension, processed.Original)
+	if err == nil {
+		upload.MediumUrl, err = save("_medium", processed.VariantExtension, processed.Medium)
+	}
+	if err == nil {
+		upload.ThumbnailUrl, err = save("_thumbnail", processed.VariantExtension, processed.Thumbnail)
+	}
+	if err == nil {
+		upload, err = s.uploadRepo.Save(upload)
+	}
+	if err != nil {
+		for _, url := range saved {
+			_ = s.imageStorage.Delete(url)
+		}
+		return domain.Upload{}, err
+	}
+	return upload, nil
+}
+
+func takeImageUpload(uploadRepo repositories.UploadRepository, id uuid.UUID, userId uint64) (domain.Upload, error) {
+	upload, err := uploadRepo.Take(id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Upload{}, ErrUploadNotFound
+		}
+		return domain.Upload{}, err
+	}
+	return upload, nil
+}
+
+func newImageFileName() string {
+	return "file_" + strconv.FormatInt(time.Now().UnixNano(), 32)
+}
+
+type sizeLimitReader struct {
+	reader    io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (r *sizeLimitReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.reader.Read(p)
+	if int64(n) > r.remaining {
+		r.exceeded = true
+		r.remaining = 0
+		return 0, ErrUploadTooLarge
+	}
+	r.remaining -= int64(n)
+	return n, err
+}
@@ -7,11 +7,16 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+var ErrEmailNotVerified = errors.New("email is not verified")
+
 type UserService interface {
 	UpdateUserBalance(user domain.User, amount int32) (domain.User, error)
 	FindByEmail(email string) (domain.User, error)
 	FindById(id uint64) (domain.User, error)
 	Save(user domain.User) (domain.User, error)
+	UpdatePassword(user domain.User, password string) error
+	MarkVerified(user domain.User) error
+	UpdateEmail(user domain.User, email string) error
 	Delete(id uint64) error
 }
 
@@ -26,6 +31,9 @@ func NewUserService(userRepository repositories.UserRepository) UserService {
 }
 
 func (u userService) UpdateUserBalance(user domain.User, amount int32) (domain.User, error) {
+	if amount < 0 && !user.Verified {
+		return domain.User{}, ErrEmailNotVerified
+	}
 	if user.Points+amount < 0 {
 		return domain.User{}, errors.New("insufficient funds")
 	}
@@ -69,6 +77,23 @@ func (u userService) Save(user domain.User) (domain.User, error) {
 	return user, nil
 }
 
+func (u userService) UpdatePassword(user domain.User, password string) error {
+	passwordHash, err := generatePasswordHash(password)
+	if err != nil {
+		return err
+	}
+
+	return u.userRepo.UpdatePassword(user.Id, passwordHash)
+}
+
+func (u userService) MarkVerified(user domain.User) error {
+	return u.userRepo.MarkVerified(user.Id)
+}
+
+func (u userService) UpdateEmail(user domain.User, email string) error {
+	return u.userRepo.UpdateEmail(user.Id, email)
+}
+
 func (u userService) Delete(id uint64) error {
 	err := u.userRepo.Delete(id)
 	if err != nil {
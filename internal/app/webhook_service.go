@@ -0,0 +1,298 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+
+	webhookMaxAttempts    = 5
+	webhookInitialBackoff = time.Minute
+	webhookPollInterval   = time.Second
+	webhookBatchSize      = 100
+	webhookClaimLease     = time.Minute
+)
+
+var ErrWebhookUrlNotAllowed = errors.New("webhook url must be a public https address")
+
+var (
+	thisNetwork        = &net.IPNet{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)}
+	sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+)
+
+type WebhookService interface {
+	Find(id uint64) (domain.Webhook, error)
+	FindByUserId(userId uint64) ([]domain.Webhook, error)
+	FindDeliveries(webhookId uint64, page, limit int32) (domain.WebhookDeliveries, error)
+	Save(webhook domain.Webhook) (domain.Webhook, error)
+	Delete(id uint64) error
+	HandleEvent(event domain.Event) error
+	Run(ctx context.Context)
+}
+
+type webhookService struct {
+	webhookRepo    repositories.WebhookRepository
+	deliveryRepo   repositories.WebhookDeliveryRepository
+	jobRepo        repositories.WebhookJobRepository
+	httpClient     *http.Client
+	initialBackoff time.Duration
+}
+
+type webhookPayload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      any       `json:"data"`
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, deliveryRepo repositories.WebhookDeliveryRepository, jobRepo repositories.WebhookJobRepository) WebhookService {
+	return webhookService{
+		webhookRepo:    webhookRepo,
+		deliveryRepo:   deliveryRepo,
+		jobRepo:        jobRepo,
+		httpClient:     newWebhookHttpClient(),
+		initialBackoff: webhookInitialBackoff,
+	}
+}
+
+func (s webhookService) Find(id uint64) (domain.Webhook, error) {
+	webhook, err := s.webhookRepo.FindById(id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s webhookService) FindByUserId(userId uint64) ([]domain.Webhook, error) {
+	webhooks, err := s.webhookRepo.FindByUserId(userId)
+	if err != nil {
+		return []domain.Webhook{}, err
+	}
+	return webhooks, nil
+}
+
+func (s webhookService) FindDeliveries(webhookId uint64, page, limit int32) (domain.WebhookDeliveries, error) {
+	deliveries, err := s.deliveryRepo.FindByWebhookId(webhookId, page, limit)
+	if err != nil {
+		return domain.WebhookDeliveries{}, err
+	}
+	return deliveries, nil
+}
+
+func (s webhookService) Save(webhook domain.Webhook) (domain.Webhook, error) {
+	err := checkWebhookUrl(webhook.Url)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	secret, err := generateRandomToken(32)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	webhook.Secret = secret
+
+	webhook, err = s.webhookRepo.Save(webhook)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s webhookService) Delete(id uint64) error {
+	return s.webhookRepo.Delete(id)
+}
+
+func (s webhookService) HandleEvent(event domain.Event) error {
+	webhooks, err := s.webhookRepo.FindByUserIdAndEvent(event.UserId, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		Event:     event.Type,
+		CreatedAt: event.CreatedDate.UTC(),
+		Data:      event.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	jobs := make([]domain.WebhookJob, len(webhooks))
+	for i, webhook := range webhooks {
+		jobs[i] = domain.WebhookJob{
+			WebhookId: webhook.Id,
+			EventId:   event.Id,
+			Event:     event.Type,
+			Payload:   string(body),
+		}
+	}
+	return s.jobRepo.Enqueue(jobs)
+}
+
+func (s webhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.deliverDue()
+		}
+	}
+}
+
+func (s webhookService) deliverDue() {
+	jobs, err := s.jobRepo.ClaimDue(webhookBatchSize, webhookClaimLease)
+	if err != nil {
+		log.Printf("Webhook service deliverDue.ClaimDue: %s", err)
+		return
+	}
+
+	for _, job := range jobs {
+		err = s.deliver(job)
+		if err != nil {
+			log.Printf("Webhook service deliver (job %d): %s", job.Id, err)
+		}
+	}
+}
+
+func (s webhookService) deliver(job domain.WebhookJob) error {
+	webhook, err := s.webhookRepo.FindById(job.WebhookId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return s.jobRepo.Delete(job.Id)
+		}
+		return err
+	}
+
+	delivery := domain.WebhookDelivery{
+		WebhookId: webhook.Id,
+		Event:     job.Event,
+		Payload:   job.Payload,
+		Attempt:   job.Attempt + 1,
+	}
+	statusCode, err := s.send(webhook, job.Event, []byte(job.Payload))
+	delivery.StatusCode = int32(statusCode)
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.Success = true
+	}
+
+	_, err = s.deliveryRepo.Save(delivery)
+	if err != nil {
+		log.Printf("Webhook service deliver.SaveDelivery: %s", err)
+	}
+
+	if delivery.Success || delivery.Attempt >= webhookMaxAttempts {
+		return s.jobRepo.Delete(job.Id)
+	}
+	return s.jobRepo.Reschedule(job.Id, delivery.Attempt, s.initialBackoff<<(delivery.Attempt-1))
+}
+
+func (s webhookService) send(webhook domain.Webhook, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, eventType)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(webhook.Secret, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func checkWebhookUrl(rawUrl string) error {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" {
+		return ErrWebhookUrlNotAllowed
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(context.Background(), "ip", parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrWebhookUrlNotAllowed, err)
+	}
+	for _, ip := range ips {
+		if !isPublicIp(ip) {
+			return ErrWebhookUrlNotAllowed
+		}
+	}
+	return nil
+}
+
+// newWebhookHttpClient checks the address again when dialing, the host may
+// resolve to something else by the time a delivery is made.
+func newWebhookHttpClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIp(ip) {
+				return ErrWebhookUrlNotAllowed
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isPublicIp(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!thisNetwork.Contains(ip) &&
+		!sharedAddressSpace.Contains(ip)
+}
@@ -0,0 +1,257 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+type memoryWebhookRepo struct {
+	repositories.WebhookRepository
+	webhooks []domain.Webhook
+}
+
+func (r memoryWebhookRepo) FindByUserIdAndEvent(userId uint64, eventType string) ([]domain.Webhook, error) {
+	return r.webhooks, nil
+}
+
+func (r memoryWebhookRepo) FindById(id uint64) (domain.Webhook, error) {
+	for _, webhook := range r.webhooks {
+		if webhook.Id == id {
+			return webhook, nil
+		}
+	}
+	return domain.Webhook{}, sql.ErrNoRows
+}
+
+type memoryWebhookDeliveryRepo struct {
+	repositories.WebhookDeliveryRepository
+	deliveries *[]domain.WebhookDelivery
+}
+
+func (r memoryWebhookDeliveryRepo) Save(delivery domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	*r.deliveries = append(*r.deliveries, delivery)
+	return delivery, nil
+}
+
+type memoryWebhookJobRepo struct {
+	repositories.WebhookJobRepository
+	jobs   *[]domain.WebhookJob
+	delays *[]time.Duration
+}
+
+func (r memoryWebhookJobRepo) Enqueue(jobs []domain.WebhookJob) error {
+	for _, job := range jobs {
+		if !slices.ContainsFunc(*r.jobs, func(j domain.WebhookJob) bool {
+			return j.WebhookId == job.WebhookId && j.EventId == job.EventId
+		}) {
+			job.Id = uint64(len(*r.jobs) + 1)
+			*r.jobs = append(*r.jobs, job)
+		}
+	}
+	return nil
+}
+
+func (r memoryWebhookJobRepo) ClaimDue(limit int32, lease time.Duration) ([]domain.WebhookJob, error) {
+	return append([]domain.WebhookJob{}, *r.jobs...), nil
+}
+
+func (r memoryWebhookJobRepo) Reschedule(id uint64, attempt int32, delay time.Duration) error {
+	for i := range *r.jobs {
+		if (*r.jobs)[i].Id == id {
+			(*r.jobs)[i].Attempt = attempt
+		}
+	}
+	*r.delays = append(*r.delays, delay)
+	return nil
+}
+
+func (r memoryWebhookJobRepo) Delete(id uint64) error {
+	*r.jobs = slices.DeleteFunc(*r.jobs, func(job domain.WebhookJob) bool { return job.Id == id })
+	return nil
+}
+
+type webhookRequest struct {
+	signature string
+	event     string
+	body      []byte
+}
+
+type webhookTest struct {
+	service    webhookService
+	deliveries *[]domain.WebhookDelivery
+	jobs       *[]domain.WebhookJob
+	delays     *[]time.Duration
+	requests   *[]webhookRequest
+}
+
+func newWebhookTest(t *testing.T, statuses ...int) webhookTest {
+	test := webhookTest{
+		deliveries: &[]domain.WebhookDelivery{},
+		jobs:       &[]domain.WebhookJob{},
+		delays:     &[]time.Duration{},
+		requests:   &[]webhookRequest{},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		status := statuses[min(len(*test.requests), len(statuses)-1)]
+		*test.requests = append(*test.requests, webhookRequest{
+			signature: r.Header.Get(WebhookSignatureHeader),
+			event:     r.Header.Get(WebhookEventHeader),
+			body:      body,
+		})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	test.service = webhookService{
+		webhookRepo: memoryWebhookRepo{webhooks: []domain.Webhook{
+			{Id: 4, UserId: 7, Url: server.URL, Secret: "secret"},
+		}},
+		deliveryRepo:   memoryWebhookDeliveryRepo{deliveries: test.deliveries},
+		jobRepo:        memoryWebhookJobRepo{jobs: test.jobs, delays: test.delays},
+		httpClient:     server.Client(),
+		initialBackoff: time.Minute,
+	}
+
+	err := test.service.HandleEvent(webhookEvent())
+	if err != nil {
+		t.Fatalf("HandleEvent() error = %v", err)
+	}
+	return test
+}
+
+func webhookEvent() domain.Event {
+	return domain.Event{Id: 12, Type: "party.created", UserId: 7, CreatedDate: time.Now(), Data: map[string]uint64{"id": 1}}
+}
+
+func TestWebhookEventIsQueuedOnce(t *testing.T) {
+	test := newWebhookTest(t, http.StatusOK)
+
+	err := test.service.HandleEvent(webhookEvent())
+	if err != nil {
+		t.Fatalf("HandleEvent() error = %v", err)
+	}
+	if len(*test.jobs) != 1 {
+		t.Fatalf("jobs = %+v, want one", *test.jobs)
+	}
+	if len(*test.requests) != 0 {
+		t.Fatal("HandleEvent() sent the webhook instead of queueing it")
+	}
+}
+
+func TestWebhookDeliverySignsPayload(t *testing.T) {
+	test := newWebhookTest(t, http.StatusOK)
+	test.service.deliverDue()
+
+	request := (*test.requests)[0]
+	if request.signature != "sha256="+SignWebhookPayload("secret", request.body) {
+		t.Fatalf("signature = %s, does not match the body", request.signature)
+	}
+	if request.event != "party.created" {
+		t.Fatalf("event header = %s, want party.created", request.event)
+	}
+	if len(*test.deliveries) != 1 {
+		t.Fatalf("deliveries = %+v, want one", *test.deliveries)
+	}
+	delivery := (*test.deliveries)[0]
+	if !delivery.Success || delivery.StatusCode != http.StatusOK || delivery.Attempt != 1 || delivery.WebhookId != 4 || delivery.Payload != string(request.body) {
+		t.Fatalf("delivery = %+v", delivery)
+	}
+	if len(*test.jobs) != 0 {
+		t.Fatalf("jobs = %+v, want none after success", *test.jobs)
+	}
+}
+
+func TestWebhookDeliveryRetriesWithBackoff(t *testing.T) {
+	test := newWebhookTest(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent)
+	for i := 0; i < 4; i++ {
+		test.service.deliverDue()
+	}
+
+	if want := []time.Duration{time.Minute, 2 * time.Minute}; !slices.Equal(*test.delays, want) {
+		t.Fatalf("backoff = %v, want %v", *test.delays, want)
+	}
+	wantStatuses := []int32{http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent}
+	if len(*test.deliveries) != len(wantStatuses) {
+		t.Fatalf("deliveries = %+v, want %d", *test.deliveries, len(wantStatuses))
+	}
+	for i, delivery := range *test.deliveries {
+		if delivery.Attempt != int32(i+1) || delivery.StatusCode != wantStatuses[i] {
+			t.Fatalf("delivery %d = %+v", i, delivery)
+		}
+		if success := i == 2; delivery.Success != success || (delivery.Error == "") != success {
+			t.Fatalf("delivery %d = %+v, want success %t", i, delivery, success)
+		}
+	}
+}
+
+func TestWebhookDeliveryGivesUpAfterMaxAttempts(t *testing.T) {
+	test := newWebhookTest(t, http.StatusServiceUnavailable)
+	for i := 0; i < webhookMaxAttempts+2; i++ {
+		test.service.deliverDue()
+	}
+
+	if len(*test.deliveries) != webhookMaxAttempts {
+		t.Fatalf("deliveries = %d, want %d", len(*test.deliveries), webhookMaxAttempts)
+	}
+	for _, delivery := range *test.deliveries {
+		if delivery.Success {
+			t.Fatalf("delivery = %+v, want failure", delivery)
+		}
+	}
+	if len(*test.jobs) != 0 {
+		t.Fatalf("jobs = %+v, want none after %d attempts", *test.jobs, webhookMaxAttempts)
+	}
+}
+
+func TestWebhookDeliveryDropsJobsOfDeletedWebhooks(t *testing.T) {
+	test := newWebhookTest(t, http.StatusOK)
+	test.service.webhookRepo = memoryWebhookRepo{}
+	test.service.deliverDue()
+
+	if len(*test.requests) != 0 || len(*test.jobs) != 0 {
+		t.Fatalf("requests = %d, jobs = %+v, want none", len(*test.requests), *test.jobs)
+	}
+}
+
+func TestWebhookSaveRejectsInternalUrls(t *testing.T) {
+	service := NewWebhookService(memoryWebhookRepo{}, nil, nil)
+
+	for _, url := range []string{
+		"http://example.com/hook",
+		"https://127.0.0.1/hook",
+		"https://localhost:8080/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://10.0.0.1/hook",
+		"https://192.168.1.1/hook",
+		"https://100.64.0.1/hook",
+		"https://[::1]/hook",
+		"https://[fd00::1]/hook",
+		"https://0.0.0.0/hook",
+	} {
+		_, err := service.Save(domain.Webhook{UserId: 7, Url: url})
+		if !errors.Is(err, ErrWebhookUrlNotAllowed) {
+			t.Errorf("Save(%s) error = %v, want %v", url, err, ErrWebhookUrlNotAllowed)
+		}
+	}
+}
+
+func TestWebhookClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the webhook client reached a loopback address")
+	}))
+	defer server.Close()
+
+	_, err := newWebhookHttpClient().Post(server.URL, "application/json", nil)
+	if !errors.Is(err, ErrWebhookUrlNotAllowed) {
+		t.Fatalf("Post() error = %v, want %v", err, ErrWebhookUrlNotAllowed)
+	}
+}
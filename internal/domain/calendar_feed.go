@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type CalendarFeed struct {
+	Id          uint64
+	UserId      uint64
+	Token       string
+	TokenHash   string
+	Url         string
+	CreatedDate time.Time
+}
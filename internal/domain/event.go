@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+const (
+	PartyCreatedEvent     = "party.created"
+	PartyUpdatedEvent     = "party.updated"
+	PartyDeletedEvent     = "party.deleted"
+	MemberJoinedEvent     = "member.joined"
+	MemberLeftEvent       = "member.left"
+	LikeCreatedEvent      = "like.created"
+	LikeDeletedEvent      = "like.deleted"
+	TransferReceivedEvent = "transfer.received"
+)
+
+var WebhookEventTypes = []string{
+	PartyCreatedEvent,
+	PartyUpdatedEvent,
+	MemberJoinedEvent,
+	MemberLeftEvent,
+	TransferReceivedEvent,
+}
+
+type Event struct {
+	Id          uint64
+	Type        string
+	UserId      uint64
+	Data        any
+	CreatedDate time.Time
+}
+
+type PartyEventData struct {
+	PartyId   uint64    `json:"partyId"`
+	Title     string    `json:"title"`
+	Price     int32     `json:"price"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Timezone  string    `json:"timezone"`
+	CreatorId uint64    `json:"creatorId"`
+}
+
+type MemberEventData struct {
+	PartyId uint64 `json:"partyId"`
+	UserId  uint64 `json:"userId"`
+}
+
+type LikeEventData struct {
+	LikedId uint64 `json:"likedId"`
+	LikerId uint64 `json:"likerId"`
+}
+
+type TransferEventData struct {
+	TransferId uint64  `json:"transferId"`
+	SenderId   uint64  `json:"senderId"`
+	PartyId    *uint64 `json:"partyId"`
+	Amount     int32   `json:"amount"`
+	Note       string  `json:"note"`
+}
+
+func NewPartyEvent(eventType string, party Party) Event {
+	return Event{
+		Type:   eventType,
+		UserId: party.CreatorId,
+		Data: PartyEventData{
+			PartyId:   party.Id,
+			Title:     party.Title,
+			Price:     party.Price,
+			StartDate: party.StartDate,
+			EndDate:   party.EndDate,
+			Timezone:  party.Timezone,
+			CreatorId: party.CreatorId,
+		},
+	}
+}
+
+func NewMemberEvent(eventType string, creatorId uint64, member Member) Event {
+	return Event{
+		Type:   eventType,
+		UserId: creatorId,
+		Data: MemberEventData{
+			PartyId: member.PartyId,
+			UserId:  member.UserId,
+		},
+	}
+}
+
+func NewLikeEvent(eventType string, like Like) Event {
+	return Event{
+		Type:   eventType,
+		UserId: like.LikedId,
+		Data: LikeEventData{
+			LikedId: like.LikedId,
+			LikerId: like.LikerId,
+		},
+	}
+}
+
+func NewTransferEvent(eventType string, transfer Transfer) Event {
+	return Event{
+		Type:   eventType,
+		UserId: transfer.RecipientId,
+		Data: TransferEventData{
+			TransferId: transfer.Id,
+			SenderId:   transfer.SenderId,
+			PartyId:    transfer.PartyId,
+			Amount:     transfer.Amount,
+			Note:       transfer.Note,
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+const (
+	LoginFailureUnknownEmail    = "unknown_email"
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureInvalidCode     = "invalid_2fa_code"
+	LoginFailureThrottled       = "throttled"
+)
+
+type LoginAttempt struct {
+	Id          uint64
+	UserId      *uint64
+	Email       string
+	Ip          string
+	UserAgent   string
+	Success     bool
+	Reason      string
+	CreatedDate time.Time
+}
+
+type LoginAttemptStats struct {
+	Failures         int32
+	SinceLastFailure time.Duration
+}
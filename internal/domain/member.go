@@ -3,6 +3,18 @@ package domain
 type Member struct {
 	PartyId uint64
 	UserId  uint64
+	Price   int32
+	TierId  *uint64
+}
+
+type PartyJoin struct {
+	TierId    *uint64
+	PromoCode string
+}
+
+type PartyMember struct {
+	User   User
+	TierId *uint64
 }
 
 func (p Member) GetUserId() uint64 {
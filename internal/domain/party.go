@@ -1,15 +1,36 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Party struct {
-	Id          uint64
-	Title       string
-	Description string
-	Image       string
-	Price       int32
-	StartDate   time.Time
-	CreatorId   uint64
+	Id             uint64
+	Title          string
+	Description    string
+	Image          string
+	ImageMedium    string
+	ImageThumbnail string
+	ImageUploadId  uuid.UUID
+	Price          int32
+	StartDate      time.Time
+	EndDate        time.Time
+	Timezone       string
+	CreatorId      uint64
+	TipsTotal      int32
+	Tiers          []TicketTier
+	Images         []PartyImage
+	SeriesId       *uint64
+	Recurrence     string
+	CreatedDate    time.Time
+	UpdatedDate    time.Time
+}
+
+type PartyFilter struct {
+	HappeningNow bool
+	Upcoming     bool
 }
 
 type Parties struct {
@@ -19,6 +40,35 @@ type Parties struct {
 	LastPage    int32
 }
 
+func (p Party) HasTiers() bool {
+	return len(p.Tiers) > 0
+}
+
+func (p Party) FindTier(id uint64) (TicketTier, bool) {
+	for _, tier := range p.Tiers {
+		if tier.Id == id {
+			return tier, true
+		}
+	}
+	return TicketTier{}, false
+}
+
 func (p Party) GetUserId() uint64 {
 	return p.CreatorId
 }
+
+func (p Party) IsFinished() bool {
+	return p.EndDate.Before(time.Now())
+}
+
+func (p Party) Duration() time.Duration {
+	return p.EndDate.Sub(p.StartDate)
+}
+
+func (p Party) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil || p.Timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PartyImageKindHost    = "host"
+	PartyImageKindGallery = "gallery"
+
+	PartyImageStatusPending  = "pending"
+	PartyImageStatusApproved = "approved"
+
+	MaxPartyImages   = 10
+	MaxGalleryImages = 200
+)
+
+type PartyImage struct {
+	Id           uint64
+	PartyId      uint64
+	UserId       uint64
+	Url          string
+	MediumUrl    string
+	ThumbnailUrl string
+	UploadId     uuid.UUID
+	Kind         string
+	Status       string
+	Position     int32
+	IsCover      bool
+	CreatedDate  time.Time
+}
+
+func (i PartyImage) IsApproved() bool {
+	return i.Status == PartyImageStatusApproved
+}
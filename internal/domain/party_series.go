@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+const (
+	SeriesScopeThis      = "this"
+	SeriesScopeFollowing = "following"
+	SeriesScopeAll       = "all"
+)
+
+type PartySeries struct {
+	Id          uint64
+	CreatorId   uint64
+	Rule        string
+	CreatedDate time.Time
+	Parties     []Party
+}
+
+func (s PartySeries) GetUserId() uint64 {
+	return s.CreatorId
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ScopeUsersRead     = "users:read"
+	ScopeUsersWrite    = "users:write"
+	ScopePartiesRead   = "parties:read"
+	ScopePartiesWrite  = "parties:write"
+	ScopeWebhooksRead  = "webhooks:read"
+	ScopeWebhooksWrite = "webhooks:write"
+)
+
+var PersonalAccessTokenScopes = []string{
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopePartiesRead,
+	ScopePartiesWrite,
+	ScopeWebhooksRead,
+	ScopeWebhooksWrite,
+}
+
+type PersonalAccessToken struct {
+	Id           uint64
+	UserId       uint64
+	Name         string
+	Token        string
+	TokenHash    string
+	Prefix       string
+	Scopes       []string
+	ExpiresDate  *time.Time
+	LastUsedDate *time.Time
+	CreatedDate  time.Time
+}
+
+func (t PersonalAccessToken) GetUserId() uint64 {
+	return t.UserId
+}
+
+func (t PersonalAccessToken) HasScope(scope string) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+func (t PersonalAccessToken) IsExpired() bool {
+	return t.ExpiresDate != nil && time.Now().After(*t.ExpiresDate)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+const (
+	PointTransactionAdminAdjustment = "admin_adjustment"
+	PointTransactionTopUp           = "top_up"
+	PointTransactionTransfer        = "transfer"
+	PointTransactionTip             = "tip"
+	PointTransactionTicket          = "ticket"
+	PointTransactionRefund          = "refund"
+	PointTransactionPartySeries     = "party_series"
+)
+
+type PointTransaction struct {
+	Id          uint64
+	UserId      uint64
+	Amount      int32
+	Balance     int32
+	Type        string
+	Reason      string
+	ActorId     *uint64
+	CreatedDate time.Time
+}
+
+type BalanceAdjustment struct {
+	Amount int32
+	Reason string
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+type PromoCode struct {
+	Id             uint64
+	PartyId        uint64
+	Code           string
+	DiscountType   string
+	DiscountValue  int32
+	MaxRedemptions *int32
+	Redemptions    int32
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	CreatedDate    time.Time
+}
+
+type PromoRedemption struct {
+	Id          uint64
+	PromoCodeId uint64
+	UserId      uint64
+	Price       int32
+	Discount    int32
+	CreatedDate time.Time
+}
+
+func (p PromoCode) IsActive(now time.Time) bool {
+	if p.MaxRedemptions != nil && p.Redemptions >= *p.MaxRedemptions {
+		return false
+	}
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false
+	}
+	if p.ValidUntil != nil && !now.Before(*p.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+func (p PromoCode) Apply(price int32) int32 {
+	var discount int32
+	switch p.DiscountType {
+	case DiscountPercent:
+		discount = price * p.DiscountValue / 100
+	case DiscountFixed:
+		discount = p.DiscountValue
+	}
+	if discount > price {
+		return 0
+	}
+	return price - discount
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+
+	MaxSeriesOccurrences = 52
+)
+
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+type RecurrenceRule struct {
+	Frequency string
+	Interval  int
+	Count     int
+	Until     *time.Time
+	ByDay     []time.Weekday
+}
+
+func ParseRecurrenceRule(rule string) (RecurrenceRule, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if !strings.Contains(rule, "=") {
+		rule = "FREQ=" + rule
+	}
+
+	result := RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return RecurrenceRule{}, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+
+		switch key {
+		case "FREQ":
+			if value != FrequencyDaily && value != FrequencyWeekly && value != FrequencyMonthly {
+				return RecurrenceRule{}, fmt.Errorf("%w: unsupported frequency %s", ErrInvalidRecurrence, value)
+			}
+			result.Frequency = value
+		case "INTERVAL", "COUNT":
+			number, err := strconv.Atoi(value)
+			if err != nil || number < 1 {
+				return RecurrenceRule{}, fmt.Errorf("%w: %s must be a positive number", ErrInvalidRecurrence, key)
+			}
+			if key == "INTERVAL" {
+				result.Interval = number
+			} else {
+				result.Count = number
+			}
+		case "UNTIL":
+			until, err := parseRruleTime(value)
+			if err != nil {
+				return RecurrenceRule{}, fmt.Errorf("%w: %s", ErrInvalidRecurrence, err)
+			}
+			result.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return RecurrenceRule{}, fmt.Errorf("%w: unknown day %s", ErrInvalidRecurrence, day)
+				}
+				result.ByDay = append(result.ByDay, weekday)
+			}
+		default:
+			return RecurrenceRule{}, fmt.Errorf("%w: unsupported part %s", ErrInvalidRecurrence, key)
+		}
+	}
+
+	if result.Frequency == "" {
+		return RecurrenceRule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	}
+	if len(result.ByDay) > 0 && result.Frequency != FrequencyWeekly {
+		return RecurrenceRule{}, fmt.Errorf("%w: BYDAY is only supported for weekly rules", ErrInvalidRecurrence)
+	}
+	if result.Count > MaxSeriesOccurrences {
+		return RecurrenceRule{}, fmt.Errorf("%w: COUNT must not exceed %d", ErrInvalidRecurrence, MaxSeriesOccurrences)
+	}
+	sort.Slice(result.ByDay, func(i, j int) bool {
+		return mondayOffset(result.ByDay[i]) < mondayOffset(result.ByDay[j])
+	})
+
+	return result, nil
+}
+
+func parseRruleTime(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %s", value)
+}
+
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Frequency}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = strings.ToUpper(day.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r RecurrenceRule) Occurrences(start time.Time) []time.Time {
+	limit := MaxSeriesOccurrences
+	if r.Count > 0 {
+		limit = r.Count
+	}
+
+	var occurrences []time.Time
+	add := func(t time.Time) bool {
+		if r.Until != nil && t.After(*r.Until) {
+			return false
+		}
+		occurrences = append(occurrences, t)
+		return len(occurrences) < limit
+	}
+
+	// Guards against rules that never match, like the 31st of every other month.
+	for step := 0; step < MaxSeriesOccurrences*12; step++ {
+		switch r.Frequency {
+		case FrequencyDaily:
+			if !add(start.AddDate(0, 0, step*r.Interval)) {
+				return occurrences
+			}
+		case FrequencyWeekly:
+			if len(r.ByDay) == 0 {
+				if !add(start.AddDate(0, 0, 7*step*r.Interval)) {
+					return occurrences
+				}
+				continue
+			}
+			weekStart := start.AddDate(0, 0, 7*step*r.Interval-mondayOffset(start.Weekday()))
+			for _, day := range r.ByDay {
+				t := weekStart.AddDate(0, 0, mondayOffset(day))
+				if t.Before(start) {
+					continue
+				}
+				if !add(t) {
+					return occurrences
+				}
+			}
+		case FrequencyMonthly:
+			t := start.AddDate(0, step*r.Interval, 0)
+			// Months without this day are skipped, as RFC 5545 does.
+			if t.Day() != start.Day() {
+				continue
+			}
+			if !add(t) {
+				return occurrences
+			}
+		default:
+			return occurrences
+		}
+	}
+	return occurrences
+}
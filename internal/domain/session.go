@@ -1,8 +1,50 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Session struct {
-	UserId uint64
-	UUID   uuid.UUID
+	UserId       uint64
+	UUID         uuid.UUID
+	CreatedDate  time.Time
+	LastSeenDate time.Time
+	UserAgent    string
+	Ip           string
+}
+
+type ClientInfo struct {
+	UserAgent string
+	Ip        string
+}
+
+type AuthTokens struct {
+	AccessToken     string
+	RefreshToken    string
+	AccessExpiresAt time.Time
+	ChallengeToken  string
+}
+
+func (t AuthTokens) TwoFactorRequired() bool {
+	return t.ChallengeToken != ""
+}
+
+type RefreshToken struct {
+	Id          uint64
+	UserId      uint64
+	SessionUUID uuid.UUID
+	TokenHash   string
+	ExpiresDate time.Time
+	UsedDate    *time.Time
+	CreatedDate time.Time
+}
+
+func (t RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresDate)
+}
+
+func (t RefreshToken) Session() Session {
+	return Session{UserId: t.UserId, UUID: t.SessionUUID}
 }
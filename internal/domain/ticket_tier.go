@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type TicketTier struct {
+	Id          uint64
+	PartyId     uint64
+	Name        string
+	Price       int32
+	Capacity    int32
+	Sold        int32
+	CreatedDate time.Time
+}
+
+func (t TicketTier) Remaining() int32 {
+	if t.Sold >= t.Capacity {
+		return 0
+	}
+	return t.Capacity - t.Sold
+}
+
+func LowestTierPrice(tiers []TicketTier) int32 {
+	var lowest int32
+	for i, tier := range tiers {
+		if i == 0 || tier.Price < lowest {
+			lowest = tier.Price
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+const (
+	TopUpPending   = "pending"
+	TopUpCompleted = "completed"
+	TopUpFailed    = "failed"
+
+	PaymentEventCheckoutCompleted = "checkout.completed"
+	PaymentEventCheckoutFailed    = "checkout.failed"
+)
+
+type TopUp struct {
+	Id                uint64
+	UserId            uint64
+	Amount            int32
+	Provider          string
+	ProviderSessionId string
+	CheckoutUrl       string
+	Status            string
+	CompletedDate     *time.Time
+	CreatedDate       time.Time
+}
+
+func (t TopUp) GetUserId() uint64 {
+	return t.UserId
+}
+
+type CheckoutSession struct {
+	Id  string
+	Url string
+}
+
+type PaymentEvent struct {
+	Id        string
+	Type      string
+	SessionId string
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type Transfer struct {
+	Id          uint64
+	SenderId    uint64
+	RecipientId uint64
+	PartyId     *uint64
+	Amount      int32
+	Note        string
+	Balance     int32
+	CreatedDate time.Time
+}
+
+func (t Transfer) GetUserId() uint64 {
+	return t.SenderId
+}
+
+func (t Transfer) IsTip() bool {
+	return t.PartyId != nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Upload struct {
+	Id           uuid.UUID
+	UserId       uint64
+	Url          string
+	MediumUrl    string
+	ThumbnailUrl string
+	ContentType  string
+	Size         int64
+	CreatedDate  time.Time
+}
@@ -1,17 +1,83 @@
 package domain
 
+import "time"
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
 type User struct {
-	Id       uint64
-	Name     string
+	Id          uint64
+	Name        string
+	Email       string
+	Password    string
+	Points      int32
+	Verified    bool
+	TotpSecret  string
+	TotpEnabled bool
+	Role        Role
+	BannedDate  *time.Time
+	BanReason   string
+}
+
+type Users struct {
+	Users       []User
+	Total       uint64
+	CurrentPage int32
+	LastPage    int32
+}
+
+type UserBan struct {
+	Reason string
+}
+
+type RoleChange struct {
+	Role Role
+}
+
+type PasswordChange struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+type EmailChange struct {
 	Email    string
 	Password string
-	Points   int32
 }
 
-type UpdateUserBalanceAmount struct {
-	Amount int32
+type TotpEnrollment struct {
+	Secret string
+	Uri    string
+}
+
+type TwoFactorVerification struct {
+	ChallengeToken string
+	Code           string
 }
 
 func (u User) GetUserId() uint64 {
 	return u.Id
 }
+
+func (u User) HasRole(role Role) bool {
+	return roleRanks[u.Role] >= roleRanks[role]
+}
+
+func (u User) IsBanned() bool {
+	return u.BannedDate != nil
+}
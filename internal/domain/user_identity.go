@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+type UserIdentity struct {
+	Id          uint64
+	UserId      uint64
+	Provider    string
+	Subject     string
+	Email       string
+	CreatedDate time.Time
+}
+
+type OidcProfile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type OidcState struct {
+	StateHash    string
+	Provider     string
+	CodeVerifier string
+	ExpiresDate  time.Time
+	CreatedDate  time.Time
+}
+
+func (s OidcState) IsExpired() bool {
+	return time.Now().After(s.ExpiresDate)
+}
+
+type OidcCallback struct {
+	Provider     string
+	Code         string
+	State        string
+	BrowserState string
+}
+
+type OidcExchange struct {
+	Code string
+}
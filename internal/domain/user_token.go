@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+const (
+	PasswordResetPurpose     = "password_reset"
+	EmailVerificationPurpose = "email_verification"
+	EmailChangePurpose       = "email_change"
+	OidcLoginPurpose         = "oidc_login"
+)
+
+type UserToken struct {
+	Id          uint64
+	UserId      uint64
+	Purpose     string
+	TokenHash   string
+	Payload     string
+	ExpiresDate time.Time
+	UsedDate    *time.Time
+	CreatedDate time.Time
+}
+
+type PasswordReset struct {
+	Token    string
+	Password string
+}
+
+type EmailVerification struct {
+	Token string
+}
+
+func (t UserToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresDate)
+}
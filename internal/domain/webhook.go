@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type Webhook struct {
+	Id          uint64
+	UserId      uint64
+	Url         string
+	Secret      string
+	Events      []string
+	CreatedDate time.Time
+}
+
+type WebhookDelivery struct {
+	Id          uint64
+	WebhookId   uint64
+	Event       string
+	Payload     string
+	Attempt     int32
+	StatusCode  int32
+	Success     bool
+	Error       string
+	CreatedDate time.Time
+}
+
+type WebhookJob struct {
+	Id              uint64
+	WebhookId       uint64
+	EventId         uint64
+	Event           string
+	Payload         string
+	Attempt         int32
+	NextAttemptDate time.Time
+	CreatedDate     time.Time
+}
+
+type WebhookDeliveries struct {
+	Deliveries  []WebhookDelivery
+	Total       uint64
+	CurrentPage int32
+	LastPage    int32
+}
+
+func (w Webhook) GetUserId() uint64 {
+	return w.UserId
+}
+
+func (w Webhook) IsSubscribed(eventType string) bool {
+	return slices.Contains(w.Events, eventType)
+}
@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"fmt"
+	"go-rest-api/internal/domain"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+type Event struct {
+	Uid         string
+	Summary     string
+	Description string
+	Url         string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+}
+
+func FromParties(name string, parties []domain.Party, frontendUrl string) Calendar {
+	host := "party-app.local"
+	parsed, err := url.Parse(frontendUrl)
+	if err == nil && parsed.Hostname() != "" {
+		host = parsed.Hostname()
+	}
+
+	now := time.Now()
+	events := make([]Event, len(parties))
+	for i, party := range parties {
+		events[i] = Event{
+			Uid:         fmt.Sprintf("party-%d@%s", party.Id, host),
+			Summary:     party.Title,
+			Description: party.Description,
+			Url:         fmt.Sprintf("%s/party/%d", strings.TrimSuffix(frontendUrl, "/"), party.Id),
+			Start:       party.StartDate,
+			End:         party.EndDate,
+			Stamp:       now,
+		}
+	}
+	return Calendar{Name: name, Events: events}
+}
+
+func (c Calendar) Encode() []byte {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//Party App//Parties//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+event.Uid)
+		writeLine(&b, "DTSTAMP:"+formatTime(event.Stamp))
+		writeLine(&b, "DTSTART:"+formatTime(event.Start))
+		writeLine(&b, "DTEND:"+formatTime(event.End))
+		writeLine(&b, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Url != "" {
+			writeLine(&b, "URL:"+event.Url)
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+func escapeText(text string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(text)
+}
+
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
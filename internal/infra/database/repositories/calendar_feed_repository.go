@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+const calendarFeedColumns = `id, user_id, token_hash, created_date`
+
+type calendarFeed struct {
+	Id          uint64    `db:"id, omitempty"`
+	UserId      uint64    `db:"user_id"`
+	TokenHash   string    `db:"token_hash"`
+	CreatedDate time.Time `db:"created_date"`
+}
+
+type CalendarFeedRepository interface {
+	FindByHash(tokenHash string) (domain.CalendarFeed, error)
+	Save(feed domain.CalendarFeed) (domain.CalendarFeed, error)
+	DeleteByUserId(userId uint64) error
+}
+
+type calendarFeedRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarFeedRepository(db *sql.DB) CalendarFeedRepository {
+	return calendarFeedRepository{db: db}
+}
+
+func (cr calendarFeedRepository) FindByHash(tokenHash string) (domain.CalendarFeed, error) {
+	sqlCommand := `SELECT ` + calendarFeedColumns + ` FROM calendar_feeds WHERE token_hash = $1`
+	feedModel, err := cr.scan(cr.db.QueryRow(sqlCommand, tokenHash))
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	return cr.modelToDomain(feedModel), nil
+}
+
+func (cr calendarFeedRepository) Save(feed domain.CalendarFeed) (domain.CalendarFeed, error) {
+	feedModel := cr.domainToModel(feed)
+	sqlCommand := `INSERT INTO calendar_feeds (user_id, token_hash) VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_date = NOW()
+	RETURNING id, created_date`
+	err := cr.db.QueryRow(sqlCommand, feedModel.UserId, feedModel.TokenHash).Scan(
+		&feedModel.Id,
+		&feedModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	return cr.modelToDomain(feedModel), nil
+}
+
+func (cr calendarFeedRepository) DeleteByUserId(userId uint64) error {
+	sqlCommand := `DELETE FROM calendar_feeds WHERE user_id = $1`
+	_, err := cr.db.Exec(sqlCommand, userId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cr calendarFeedRepository) scan(row interface{ Scan(dest ...any) error }) (calendarFeed, error) {
+	feedModel := calendarFeed{}
+	err := row.Scan(
+		&feedModel.Id,
+		&feedModel.UserId,
+		&feedModel.TokenHash,
+		&feedModel.CreatedDate,
+	)
+	return feedModel, err
+}
+
+func (cr calendarFeedRepository) domainToModel(f domain.CalendarFeed) calendarFeed {
+	return calendarFeed{
+		Id:          f.Id,
+		UserId:      f.UserId,
+		TokenHash:   f.TokenHash,
+		CreatedDate: f.CreatedDate,
+	}
+}
+
+func (cr calendarFeedRepository) modelToDomain(f calendarFeed) domain.CalendarFeed {
+	return domain.CalendarFeed{
+		Id:          f.Id,
+		UserId:      f.UserId,
+		TokenHash:   f.TokenHash,
+		CreatedDate: f.CreatedDate,
+	}
+}
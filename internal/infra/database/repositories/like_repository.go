@@ -33,12 +33,14 @@ func (l likeRepository) Save(domainLike domain.Like) error {
 	likeModel := l.DomainToModel(domainLike)
 	sqlCommand := `INSERT INTO likes (liked_id, liker_id) VALUES ($1, $2)`
 
-	_, err := l.db.Exec(sqlCommand, likeModel.LikedId, likeModel.LikerId)
-	if err != nil {
-		return err
-	}
+	return withTransaction(l.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(sqlCommand, likeModel.LikedId, likeModel.LikerId)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return saveEvent(tx, domain.NewLikeEvent(domain.LikeCreatedEvent, domainLike))
+	})
 }
 
 func (l likeRepository) FindByLikedId(likedId uint64) ([]domain.Like, error) {
@@ -99,12 +101,18 @@ func (l likeRepository) Delete(domainLike domain.Like) error {
 	likeModel := l.DomainToModel(domainLike)
 	sqlCommand := `DELETE FROM likes WHERE liked_id = $1 AND liker_id = $2`
 
-	_, err := l.db.Exec(sqlCommand, likeModel.LikedId, likeModel.LikerId)
-	if err != nil {
-		return err
-	}
+	return withTransaction(l.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(sqlCommand, likeModel.LikedId, likeModel.LikerId)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil || affected == 0 {
+			return err
+		}
 
-	return nil
+		return saveEvent(tx, domain.NewLikeEvent(domain.LikeDeletedEvent, domainLike))
+	})
 }
 
 func (l likeRepository) Exists(domainLike domain.Like) error {
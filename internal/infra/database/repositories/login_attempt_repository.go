@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type loginAttempt struct {
+	Id          uint64        `db:"id, omitempty"`
+	UserId      sql.NullInt64 `db:"user_id"`
+	Email       string        `db:"email"`
+	Ip          string        `db:"ip"`
+	UserAgent   string        `db:"user_agent"`
+	Success     bool          `db:"success"`
+	Reason      string        `db:"reason"`
+	CreatedDate time.Time     `db:"created_date"`
+}
+
+type LoginAttemptRepository interface {
+	Save(attempt domain.LoginAttempt) (domain.LoginAttempt, error)
+	StatsByEmail(email string, window time.Duration) (domain.LoginAttemptStats, error)
+	StatsByIp(ip string, window time.Duration) (domain.LoginAttemptStats, error)
+}
+
+type loginAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptRepository(db *sql.DB) LoginAttemptRepository {
+	return loginAttemptRepository{db: db}
+}
+
+func (la loginAttemptRepository) Save(attempt domain.LoginAttempt) (domain.LoginAttempt, error) {
+	attemptModel := la.domainToModel(attempt)
+	sqlCommand := `INSERT INTO login_attempts (user_id, email, ip, user_agent, success, reason)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_date`
+	err := la.db.QueryRow(
+		sqlCommand,
+		attemptModel.UserId,
+		attemptModel.Email,
+		attemptModel.Ip,
+		attemptModel.UserAgent,
+		attemptModel.Success,
+		attemptModel.Reason,
+	).Scan(
+		&attemptModel.Id,
+		&attemptModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.LoginAttempt{}, err
+	}
+	return la.modelToDomain(attemptModel), nil
+}
+
+func (la loginAttemptRepository) StatsByEmail(email string, window time.Duration) (domain.LoginAttemptStats, error) {
+	sqlCommand := `SELECT COUNT(*), COALESCE(EXTRACT(EPOCH FROM NOW() - MAX(created_date)), 0) FROM login_attempts
+	WHERE email = $1 AND success = false AND reason <> $2 AND created_date > GREATEST(NOW() - make_interval(secs => $3),
+		COALESCE((SELECT MAX(created_date) FROM login_attempts WHERE email = $1 AND success = true), 'epoch'))`
+	return la.stats(sqlCommand, email, domain.LoginFailureThrottled, window.Seconds())
+}
+
+func (la loginAttemptRepository) StatsByIp(ip string, window time.Duration) (domain.LoginAttemptStats, error) {
+	sqlCommand := `SELECT COUNT(*), COALESCE(EXTRACT(EPOCH FROM NOW() - MAX(created_date)), 0) FROM login_attempts
+	WHERE ip = $1 AND success = false AND reason <> $2 AND created_date > NOW() - make_interval(secs => $3)`
+	return la.stats(sqlCommand, ip, domain.LoginFailureThrottled, window.Seconds())
+}
+
+func (la loginAttemptRepository) stats(sqlCommand string, args ...any) (domain.LoginAttemptStats, error) {
+	var (
+		stats   domain.LoginAttemptStats
+		elapsed float64
+	)
+	err := la.db.QueryRow(sqlCommand, args...).Scan(
+		&stats.Failures,
+		&elapsed,
+	)
+	if err != nil {
+		return domain.LoginAttemptStats{}, err
+	}
+	stats.SinceLastFailure = time.Duration(elapsed * float64(time.Second))
+	return stats, nil
+}
+
+func (la loginAttemptRepository) domainToModel(a domain.LoginAttempt) loginAttempt {
+	model := loginAttempt{
+		Id:          a.Id,
+		Email:       a.Email,
+		Ip:          a.Ip,
+		UserAgent:   a.UserAgent,
+		Success:     a.Success,
+		Reason:      a.Reason,
+		CreatedDate: a.CreatedDate,
+	}
+	if a.UserId != nil {
+		model.UserId = sql.NullInt64{Int64: int64(*a.UserId), Valid: true}
+	}
+	return model
+}
+
+func (la loginAttemptRepository) modelToDomain(a loginAttempt) domain.LoginAttempt {
+	attempt := domain.LoginAttempt{
+		Id:          a.Id,
+		Email:       a.Email,
+		Ip:          a.Ip,
+		UserAgent:   a.UserAgent,
+		Success:     a.Success,
+		Reason:      a.Reason,
+		CreatedDate: a.CreatedDate,
+	}
+	if a.UserId.Valid {
+		userId := uint64(a.UserId.Int64)
+		attempt.UserId = &userId
+	}
+	return attempt
+}
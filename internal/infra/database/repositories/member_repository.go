@@ -3,12 +3,15 @@ package repositories
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"go-rest-api/internal/domain"
 )
 
 type member struct {
-	PartyId uint64 `db:"party_id"`
-	UserId  uint64 `db:"user_id"`
+	PartyId uint64        `db:"party_id"`
+	UserId  uint64        `db:"user_id"`
+	Price   int32         `db:"price"`
+	TierId  sql.NullInt64 `db:"tier_id"`
 }
 
 type memberRepository struct {
@@ -16,7 +19,7 @@ type memberRepository struct {
 }
 
 type MemberRepository interface {
-	Save(domainMember domain.Member) error
+	Save(domainMember domain.Member, redemption *domain.PromoRedemption) error
 	Exists(domainMember domain.Member) error
 	Delete(domainMember domain.Member) error
 	FindByUserId(userId uint64) ([]domain.Member, error)
@@ -27,18 +30,63 @@ func NewMemberRepository(db *sql.DB) MemberRepository {
 	return memberRepository{db: db}
 }
 
-func (m memberRepository) Save(domainMember domain.Member) error {
+func (m memberRepository) Save(domainMember domain.Member, redemption *domain.PromoRedemption) error {
 	memberModel := m.domainToModel(domainMember)
-	sqlCommand := `INSERT INTO party_users (party_id, user_id) VALUES ($1, $2)`
-	_, err := m.db.Exec(sqlCommand, memberModel.PartyId, memberModel.UserId)
-	if err != nil {
-		return err
-	}
-	return nil
+	sqlCommand := `INSERT INTO party_users (party_id, user_id, price, tier_id) VALUES ($1, $2, $3, $4)`
+	return withTransaction(m.db, func(tx *sql.Tx) error {
+		if memberModel.TierId.Valid {
+			err := takeTicket(tx, uint64(memberModel.TierId.Int64))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(sqlCommand, memberModel.PartyId, memberModel.UserId, memberModel.Price, memberModel.TierId)
+		if err != nil {
+			return err
+		}
+
+		if redemption != nil {
+			err = redeemPromoCode(tx, *redemption)
+			if err != nil {
+				return err
+			}
+		}
+
+		if memberModel.Price > 0 {
+			var creatorId uint64
+			err = tx.QueryRow(`SELECT creator_id FROM parties WHERE id = $1`, memberModel.PartyId).Scan(&creatorId)
+			if err != nil {
+				return err
+			}
+
+			reason := fmt.Sprintf("party #%d", memberModel.PartyId)
+			err = applyPointTransaction(tx, &pointTransaction{
+				UserId: memberModel.UserId,
+				Amount: -memberModel.Price,
+				Type:   domain.PointTransactionTicket,
+				Reason: reason,
+			})
+			if err != nil {
+				return err
+			}
+			err = applyPointTransaction(tx, &pointTransaction{
+				UserId: creatorId,
+				Amount: memberModel.Price,
+				Type:   domain.PointTransactionTicket,
+				Reason: reason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return m.saveMemberEvent(tx, domain.MemberJoinedEvent, domainMember)
+	})
 }
 
 func (m memberRepository) FindByUserId(userId uint64) ([]domain.Member, error) {
-	sqlCommand := `SELECT * FROM party_users WHERE user_id = $1`
+	sqlCommand := `SELECT party_id, user_id, price, tier_id FROM party_users WHERE user_id = $1`
 	rows, err := m.db.Query(sqlCommand, userId)
 	if err != nil {
 		return []domain.Member{}, err
@@ -53,6 +101,8 @@ func (m memberRepository) FindByUserId(userId uint64) ([]domain.Member, error) {
 		err := rows.Scan(
 			&memberModel.PartyId,
 			&memberModel.UserId,
+			&memberModel.Price,
+			&memberModel.TierId,
 		)
 		if err != nil {
 			return []domain.Member{}, err
@@ -65,7 +115,7 @@ func (m memberRepository) FindByUserId(userId uint64) ([]domain.Member, error) {
 }
 
 func (m memberRepository) FindByPartyId(partyId uint64) ([]domain.Member, error) {
-	sqlCommand := `SELECT * FROM party_users WHERE party_id = $1`
+	sqlCommand := `SELECT party_id, user_id, price, tier_id FROM party_users WHERE party_id = $1`
 	rows, err := m.db.Query(sqlCommand, partyId)
 	if err != nil {
 		return []domain.Member{}, err
@@ -80,6 +130,8 @@ func (m memberRepository) FindByPartyId(partyId uint64) ([]domain.Member, error)
 		err := rows.Scan(
 			&memberModel.PartyId,
 			&memberModel.UserId,
+			&memberModel.Price,
+			&memberModel.TierId,
 		)
 		if err != nil {
 			return []domain.Member{}, err
@@ -93,13 +145,96 @@ func (m memberRepository) FindByPartyId(partyId uint64) ([]domain.Member, error)
 
 func (m memberRepository) Delete(domainMemeber domain.Member) error {
 	memberModel := m.domainToModel(domainMemeber)
-	sqlCommand := `DELETE FROM party_users WHERE user_id = $1 AND party_id = $2`
+	sqlCommand := `DELETE FROM party_users WHERE user_id = $1 AND party_id = $2 RETURNING price, tier_id`
 
-	_, err := m.db.Exec(sqlCommand, memberModel.UserId, memberModel.PartyId)
+	return withTransaction(m.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(sqlCommand, memberModel.UserId, memberModel.PartyId).Scan(&memberModel.Price, &memberModel.TierId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if memberModel.TierId.Valid {
+			err = releaseTicket(tx, uint64(memberModel.TierId.Int64))
+			if err != nil {
+				return err
+			}
+		}
+
+		err = releasePromoCode(tx, memberModel.PartyId, memberModel.UserId)
+		if err != nil {
+			return err
+		}
+
+		var creatorId uint64
+		err = tx.QueryRow(`SELECT creator_id FROM parties WHERE id = $1`, memberModel.PartyId).Scan(&creatorId)
+		if err != nil {
+			return err
+		}
+		err = refundTicket(tx, creatorId, memberModel)
+		if err != nil {
+			return err
+		}
+
+		return m.saveMemberEvent(tx, domain.MemberLeftEvent, domainMemeber)
+	})
+}
+
+func refundTicket(tx *sql.Tx, creatorId uint64, memberModel member) error {
+	if memberModel.Price <= 0 {
+		return nil
+	}
+
+	reason := fmt.Sprintf("party #%d", memberModel.PartyId)
+	err := applyPointTransaction(tx, &pointTransaction{
+		UserId: creatorId,
+		Amount: -memberModel.Price,
+		Type:   domain.PointTransactionRefund,
+		Reason: reason,
+	})
+	if err != nil {
+		return err
+	}
+	return applyPointTransaction(tx, &pointTransaction{
+		UserId: memberModel.UserId,
+		Amount: memberModel.Price,
+		Type:   domain.PointTransactionRefund,
+		Reason: reason,
+	})
+}
+
+func removeMembers(tx *sql.Tx, partyId, creatorId uint64) error {
+	rows, err := tx.Query(`DELETE FROM party_users WHERE party_id = $1 RETURNING party_id, user_id, price, tier_id`, partyId)
 	if err != nil {
 		return err
 	}
+	var members []member
+	for rows.Next() {
+		memberModel := member{}
+		err = rows.Scan(&memberModel.PartyId, &memberModel.UserId, &memberModel.Price, &memberModel.TierId)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		members = append(members, memberModel)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
 
+	for _, memberModel := range members {
+		err = refundTicket(tx, creatorId, memberModel)
+		if err != nil {
+			return err
+		}
+		err = saveEvent(tx, domain.NewMemberEvent(domain.MemberLeftEvent, creatorId, memberRepository{}.modelToDomain(memberModel)))
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -117,16 +252,38 @@ func (m memberRepository) Exists(domainMember domain.Member) error {
 	return nil
 }
 
+func (m memberRepository) saveMemberEvent(tx *sql.Tx, eventType string, domainMember domain.Member) error {
+	var creatorId uint64
+	sqlCommand := `SELECT creator_id FROM parties WHERE id = $1`
+	err := tx.QueryRow(sqlCommand, domainMember.PartyId).Scan(&creatorId)
+	if err != nil {
+		return err
+	}
+
+	return saveEvent(tx, domain.NewMemberEvent(eventType, creatorId, domainMember))
+}
+
 func (m memberRepository) domainToModel(domainMember domain.Member) member {
-	return member{
+	model := member{
 		PartyId: domainMember.PartyId,
 		UserId:  domainMember.UserId,
+		Price:   domainMember.Price,
+	}
+	if domainMember.TierId != nil {
+		model.TierId = sql.NullInt64{Int64: int64(*domainMember.TierId), Valid: true}
 	}
+	return model
 }
 
 func (m memberRepository) modelToDomain(modelMember member) domain.Member {
-	return domain.Member{
+	domainMember := domain.Member{
 		PartyId: modelMember.PartyId,
 		UserId:  modelMember.UserId,
+		Price:   modelMember.Price,
+	}
+	if modelMember.TierId.Valid {
+		tierId := uint64(modelMember.TierId.Int64)
+		domainMember.TierId = &tierId
 	}
+	return domainMember
 }
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"go-rest-api/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestMemberCanRedeemPromoCodeAgainAfterLeaving(t *testing.T) {
+	p := newPartyRefundTest(t)
+	memberRepo := NewMemberRepository(p.db)
+	promoCodeRepo := NewPromoCodeRepository(p.db)
+
+	party, err := NewPartyRepository(p.db).Save(p.party(time.Now().Add(24 * time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	promoCode, err := promoCodeRepo.Save(domain.PromoCode{PartyId: party.Id, Code: "HALF", DiscountType: domain.DiscountPercent, DiscountValue: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member := domain.Member{PartyId: party.Id, UserId: p.member.Id, Price: 15}
+	redemption := &domain.PromoRedemption{PromoCodeId: promoCode.Id, UserId: p.member.Id, Price: 15, Discount: 15}
+	for i := 0; i < 2; i++ {
+		err = memberRepo.Save(member, redemption)
+		if err != nil {
+			t.Fatalf("join %d: %v", i+1, err)
+		}
+		err = memberRepo.Delete(member)
+		if err != nil {
+			t.Fatalf("leave %d: %v", i+1, err)
+		}
+	}
+
+	promoCode, err = promoCodeRepo.FindByCode(party.Id, "HALF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promoCode.Redemptions != 0 {
+		t.Fatalf("redemptions = %d, want 0", promoCode.Redemptions)
+	}
+	if points := testUserPoints(t, p.db, p.member.Id); points != 100 {
+		t.Fatalf("member points = %d, want 100", points)
+	}
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type oidcState struct {
+	StateHash    string    `db:"state_hash"`
+	Provider     string    `db:"provider"`
+	CodeVerifier string    `db:"code_verifier"`
+	ExpiresDate  time.Time `db:"expires_date"`
+	CreatedDate  time.Time `db:"created_date"`
+}
+
+type OidcStateRepository interface {
+	Save(state domain.OidcState) (domain.OidcState, error)
+	Consume(stateHash string) (domain.OidcState, error)
+}
+
+type oidcStateRepository struct {
+	db *sql.DB
+}
+
+func NewOidcStateRepository(db *sql.DB) OidcStateRepository {
+	return oidcStateRepository{db: db}
+}
+
+func (sr oidcStateRepository) Save(state domain.OidcState) (domain.OidcState, error) {
+	stateModel := sr.domainToModel(state)
+	sqlCommand := `INSERT INTO oidc_states (state_hash, provider, code_verifier, expires_date)
+	VALUES ($1, $2, $3, $4) RETURNING created_date`
+	err := sr.db.QueryRow(
+		sqlCommand,
+		stateModel.StateHash,
+		stateModel.Provider,
+		stateModel.CodeVerifier,
+		stateModel.ExpiresDate,
+	).Scan(&stateModel.CreatedDate)
+	if err != nil {
+		return domain.OidcState{}, err
+	}
+	return sr.modelToDomain(stateModel), nil
+}
+
+func (sr oidcStateRepository) Consume(stateHash string) (domain.OidcState, error) {
+	stateModel := oidcState{}
+	sqlCommand := `DELETE FROM oidc_states WHERE state_hash = $1
+	RETURNING state_hash, provider, code_verifier, expires_date, created_date`
+	err := sr.db.QueryRow(sqlCommand, stateHash).Scan(
+		&stateModel.StateHash,
+		&stateModel.Provider,
+		&stateModel.CodeVerifier,
+		&stateModel.ExpiresDate,
+		&stateModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.OidcState{}, err
+	}
+	return sr.modelToDomain(stateModel), nil
+}
+
+func (sr oidcStateRepository) domainToModel(s domain.OidcState) oidcState {
+	return oidcState{
+		StateHash:    s.StateHash,
+		Provider:     s.Provider,
+		CodeVerifier: s.CodeVerifier,
+		ExpiresDate:  s.ExpiresDate,
+		CreatedDate:  s.CreatedDate,
+	}
+}
+
+func (sr oidcStateRepository) modelToDomain(s oidcState) domain.OidcState {
+	return domain.OidcState{
+		StateHash:    s.StateHash,
+		Provider:     s.Provider,
+		CodeVerifier: s.CodeVerifier,
+		ExpiresDate:  s.ExpiresDate,
+		CreatedDate:  s.CreatedDate,
+	}
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type outboxEvent struct {
+	Id            uint64       `db:"id, omitempty"`
+	EventType     string       `db:"event_type"`
+	UserId        uint64       `db:"user_id"`
+	Payload       string       `db:"payload"`
+	CreatedDate   time.Time    `db:"created_date"`
+	ProcessedDate sql.NullTime `db:"processed_date"`
+}
+
+type OutboxRepository interface {
+	FindPending(limit int32) ([]domain.Event, error)
+	MarkProcessed(id uint64) error
+}
+
+type outboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) OutboxRepository {
+	return outboxRepository{db: db}
+}
+
+func (o outboxRepository) FindPending(limit int32) ([]domain.Event, error) {
+	sqlCommand := `SELECT id, event_type, user_id, payload, created_date, processed_date FROM outbox 
+	WHERE processed_date IS NULL ORDER BY id LIMIT $1`
+	rows, err := o.db.Query(sqlCommand, limit)
+	if err != nil {
+		return []domain.Event{}, err
+	}
+	defer rows.Close()
+
+	events := []domain.Event{}
+	for rows.Next() {
+		eventModel := outboxEvent{}
+		err := rows.Scan(
+			&eventModel.Id,
+			&eventModel.EventType,
+			&eventModel.UserId,
+			&eventModel.Payload,
+			&eventModel.CreatedDate,
+			&eventModel.ProcessedDate,
+		)
+		if err != nil {
+			return []domain.Event{}, err
+		}
+		events = append(events, o.modelToDomain(eventModel))
+	}
+
+	return events, nil
+}
+
+func (o outboxRepository) MarkProcessed(id uint64) error {
+	sqlCommand := `UPDATE outbox SET processed_date = NOW() WHERE id = $1`
+	_, err := o.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o outboxRepository) modelToDomain(e outboxEvent) domain.Event {
+	return domain.Event{
+		Id:          e.Id,
+		Type:        e.EventType,
+		UserId:      e.UserId,
+		Data:        json.RawMessage(e.Payload),
+		CreatedDate: e.CreatedDate,
+	}
+}
+
+func saveEvent(tx *sql.Tx, event domain.Event) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	sqlCommand := `INSERT INTO outbox (event_type, user_id, payload) VALUES ($1, $2, $3)`
+	_, err = tx.Exec(sqlCommand, event.Type, event.UserId, string(payload))
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+const partyImageColumns = `id, party_id, user_id, url, medium_url, thumbnail_url, kind, status, position, is_cover, created_date`
+
+type partyImage struct {
+	Id           uint64    `db:"id, omitempty"`
+	PartyId      uint64    `db:"party_id"`
+	UserId       uint64    `db:"user_id"`
+	Url          string    `db:"url"`
+	MediumUrl    string    `db:"medium_url"`
+	ThumbnailUrl string    `db:"thumbnail_url"`
+	Kind         string    `db:"kind"`
+	Status       string    `db:"status"`
+	Position     int32     `db:"position"`
+	IsCover      bool      `db:"is_cover"`
+	CreatedDate  time.Time `db:"created_date"`
+}
+
+type PartyImageRepository interface {
+	FindById(partyId, id uint64) (domain.PartyImage, error)
+	FindByPartyId(partyId uint64, kind string) ([]domain.PartyImage, error)
+	Count(partyId uint64, kind string) (int32, error)
+	Save(image domain.PartyImage) (domain.PartyImage, error)
+	Reorder(partyId uint64, ids []uint64) error
+	SetCover(partyId, id uint64) error
+	Approve(partyId, id uint64) (domain.PartyImage, error)
+	Delete(partyId, id uint64) (domain.PartyImage, error)
+}
+
+type partyImageRepository struct {
+	db *sql.DB
+}
+
+func NewPartyImageRepository(db *sql.DB) PartyImageRepository {
+	return partyImageRepository{db: db}
+}
+
+func (pr partyImageRepository) FindById(partyId, id uint64) (domain.PartyImage, error) {
+	sqlCommand := `SELECT ` + partyImageColumns + ` FROM party_images WHERE id = $1 AND party_id = $2`
+	imageModel, err := pr.scan(pr.db.QueryRow(sqlCommand, id, partyId))
+	if err != nil {
+		return domain.PartyImage{}, err
+	}
+	return pr.modelToDomain(imageModel), nil
+}
+
+func (pr partyImageRepository) FindByPartyId(partyId uint64, kind string) ([]domain.PartyImage, error) {
+	sqlCommand := `SELECT ` + partyImageColumns + ` FROM party_images
+	WHERE party_id = $1 AND kind = $2 ORDER BY position, id`
+	rows, err := pr.db.Query(sqlCommand, partyId, kind)
+	if err != nil {
+		return []domain.PartyImage{}, err
+	}
+	defer rows.Close()
+
+	images := []domain.PartyImage{}
+	for rows.Next() {
+		imageModel, err := pr.scan(rows)
+		if err != nil {
+			return []domain.PartyImage{}, err
+		}
+		images = append(images, pr.modelToDomain(imageModel))
+	}
+	return images, rows.Err()
+}
+
+func (pr partyImageRepository) Count(partyId uint64, kind string) (int32, error) {
+	var count int32
+	err := pr.db.QueryRow(`SELECT COUNT(*) FROM party_images WHERE party_id = $1 AND kind = $2`, partyId, kind).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (pr partyImageRepository) Save(image domain.PartyImage) (domain.PartyImage, error) {
+	imageModel := pr.domainToModel(image)
+	sqlCommand := `INSERT INTO party_images (party_id, user_id, url, medium_url, thumbnail_url, kind, status, position, is_cover)
+	SELECT $1, $2, $3, $4, $5, $6::text, $7,
+		COALESCE((SELECT MAX(position) + 1 FROM party_images WHERE party_id = $1 AND kind = $6), 0),
+		$6 = 'host' AND NOT EXISTS (SELECT 1 FROM party_images WHERE party_id = $1 AND is_cover)
+	RETURNING ` + partyImageColumns
+
+	err := withTransaction(pr.db, func(tx *sql.Tx) error {
+		var err error
+		imageModel, err = pr.scan(tx.QueryRow(
+			sqlCommand,
+			imageModel.PartyId,
+			imageModel.UserId,
+			imageModel.Url,
+			imageModel.MediumUrl,
+			imageModel.ThumbnailUrl,
+			imageModel.Kind,
+			imageModel.Status,
+		))
+		if err != nil {
+			return err
+		}
+		if !imageModel.IsCover {
+			return nil
+		}
+		return syncPartyCover(tx, imageModel.PartyId)
+	})
+	if err != nil {
+		return domain.PartyImage{}, err
+	}
+	return pr.modelToDomain(imageModel), nil
+}
+
+func (pr partyImageRepository) Reorder(partyId uint64, ids []uint64) error {
+	sqlCommand := `UPDATE party_images SET position = $1 WHERE id = $2 AND party_id = $3 AND kind = 'host'`
+	return withTransaction(pr.db, func(tx *sql.Tx) error {
+		for position, id := range ids {
+			result, err := tx.Exec(sqlCommand, position, id, partyId)
+			if err != nil {
+				return err
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return sql.ErrNoRows
+			}
+		}
+		return nil
+	})
+}
+
+func (pr partyImageRepository) SetCover(partyId, id uint64) error {
+	return withTransaction(pr.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`UPDATE party_images SET is_cover = false WHERE party_id = $1 AND is_cover`, partyId)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(`UPDATE party_images SET is_cover = true WHERE id = $1 AND party_id = $2 AND kind = 'host'`, id, partyId)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+		return syncPartyCover(tx, partyId)
+	})
+}
+
+func (pr partyImageRepository) Approve(partyId, id uint64) (domain.PartyImage, error) {
+	sqlCommand := `UPDATE party_images SET status = 'approved' WHERE id = $1 AND party_id = $2 RETURNING ` + partyImageColumns
+	imageModel, err := pr.scan(pr.db.QueryRow(sqlCommand, id, partyId))
+	if err != nil {
+		return domain.PartyImage{}, err
+	}
+	return pr.modelToDomain(imageModel), nil
+}
+
+func (pr partyImageRepository) Delete(partyId, id uint64) (domain.PartyImage, error) {
+	sqlCommand := `DELETE FROM party_images WHERE id = $1 AND party_id = $2 RETURNING ` + partyImageColumns
+
+	var imageModel partyImage
+	err := withTransaction(pr.db, func(tx *sql.Tx) error {
+		var err error
+		imageModel, err = pr.scan(tx.QueryRow(sqlCommand, id, partyId))
+		if err != nil {
+			return err
+		}
+		if !imageModel.IsCover {
+			return nil
+		}
+
+		_, err = tx.Exec(`UPDATE party_images SET is_cover = true WHERE id = (
+			SELECT id FROM party_images WHERE party_id = $1 AND kind = 'host' ORDER BY position, id LIMIT 1
+		)`, partyId)
+		if err != nil {
+			return err
+		}
+		return syncPartyCover(tx, partyId)
+	})
+	if err != nil {
+		return domain.PartyImage{}, err
+	}
+	return pr.modelToDomain(imageModel), nil
+}
+
+func saveCoverImage(tx *sql.Tx, party domain.Party) error {
+	if party.Image == "" {
+		return nil
+	}
+
+	result, err := tx.Exec(`UPDATE party_images SET url = $1, medium_url = $2, thumbnail_url = $3
+	WHERE party_id = $4 AND is_cover`, party.Image, party.ImageMedium, party.ImageThumbnail, party.Id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(`INSERT INTO party_images (party_id, user_id, url, medium_url, thumbnail_url, kind, status, position, is_cover)
+	SELECT $1, $2, $3, $4, $5, 'host', 'approved',
+		COALESCE((SELECT MAX(position) + 1 FROM party_images WHERE party_id = $1 AND kind = 'host'), 0), true`,
+		party.Id, party.CreatorId, party.Image, party.ImageMedium, party.ImageThumbnail)
+	return err
+}
+
+func syncPartyCover(tx *sql.Tx, partyId uint64) error {
+	_, err := tx.Exec(`UPDATE parties SET
+	image = COALESCE(cover.url, ''),
+	image_medium = COALESCE(cover.medium_url, ''),
+	image_thumbnail = COALESCE(cover.thumbnail_url, ''),
+	updated_date = NOW()
+	FROM (SELECT $1::bigint AS party_id) AS target
+	LEFT JOIN party_images AS cover ON cover.party_id = target.party_id AND cover.is_cover
+	WHERE parties.id = target.party_id`, partyId)
+	return err
+}
+
+func (pr partyImageRepository) scan(row interface{ Scan(dest ...any) error }) (partyImage, error) {
+	imageModel := partyImage{}
+	err := row.Scan(
+		&imageModel.Id,
+		&imageModel.PartyId,
+		&imageModel.UserId,
+		&imageModel.Url,
+		&imageModel.MediumUrl,
+		&imageModel.ThumbnailUrl,
+		&imageModel.Kind,
+		&imageModel.Status,
+		&imageModel.Position,
+		&imageModel.IsCover,
+		&imageModel.CreatedDate,
+	)
+	return imageModel, err
+}
+
+func (pr partyImageRepository) domainToModel(i domain.PartyImage) partyImage {
+	return partyImage{
+		Id:           i.Id,
+		PartyId:      i.PartyId,
+		UserId:       i.UserId,
+		Url:          i.Url,
+		MediumUrl:    i.MediumUrl,
+		ThumbnailUrl: i.ThumbnailUrl,
+		Kind:         i.Kind,
+		Status:       i.Status,
+		Position:     i.Position,
+		IsCover:      i.IsCover,
+		CreatedDate:  i.CreatedDate,
+	}
+}
+
+func (pr partyImageRepository) modelToDomain(i partyImage) domain.PartyImage {
+	return domain.PartyImage{
+		Id:           i.Id,
+		PartyId:      i.PartyId,
+		UserId:       i.UserId,
+		Url:          i.Url,
+		MediumUrl:    i.MediumUrl,
+		ThumbnailUrl: i.ThumbnailUrl,
+		Kind:         i.Kind,
+		Status:       i.Status,
+		Position:     i.Position,
+		IsCover:      i.IsCover,
+		CreatedDate:  i.CreatedDate,
+	}
+}
@@ -2,28 +2,46 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"go-rest-api/internal/domain"
 	"time"
 )
 
+const partyColumns = `id, title, description, image, image_medium, image_thumbnail, price, start_date, end_date, timezone, creator_id, tips_total, series_id, created_date, updated_date`
+
 type party struct {
-	Id          uint64    `db:"id, omitempty"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	Image       string    `db:"image"`
-	Price       int32     `db:"price"`
-	StartDate   time.Time `db:"start_date"`
-	CreatorId   uint64    `db:"creator_id"`
+	Id             uint64        `db:"id, omitempty"`
+	Title          string        `db:"title"`
+	Description    string        `db:"description"`
+	Image          string        `db:"image"`
+	ImageMedium    string        `db:"image_medium"`
+	ImageThumbnail string        `db:"image_thumbnail"`
+	Price          int32         `db:"price"`
+	StartDate      time.Time     `db:"start_date"`
+	EndDate        time.Time     `db:"end_date"`
+	Timezone       string        `db:"timezone"`
+	CreatorId      uint64        `db:"creator_id"`
+	TipsTotal      int32         `db:"tips_total"`
+	SeriesId       sql.NullInt64 `db:"series_id"`
+	CreatedDate    time.Time     `db:"created_date"`
+	UpdatedDate    time.Time     `db:"updated_date"`
 }
 
 type PartyRepository interface {
 	FindById(id uint64) (domain.Party, error)
-	FindByCreatorId(creatorId uint64, page, limit int32) (domain.Parties, error)
+	FindByCreatorId(creatorId uint64, filter domain.PartyFilter, page, limit int32) (domain.Parties, error)
 	FindPartiesByLikerId(likerId uint64, page, limit int32) (domain.Parties, error)
-	GetParties(page, limit int32) (domain.Parties, error)
+	FindJoinedOrHosted(userId uint64, from, to time.Time) ([]domain.Party, error)
+	GetParties(filter domain.PartyFilter, page, limit int32) (domain.Parties, error)
 	Save(party domain.Party) (domain.Party, error)
 	Update(party domain.Party) (domain.Party, error)
 	Delete(id uint64) error
+	FindSeriesById(id uint64) (domain.PartySeries, error)
+	SaveSeries(series domain.PartySeries, parties []domain.Party, cost int32) (domain.PartySeries, error)
+	UpdateSeries(party domain.Party, shift time.Duration, from *time.Time) ([]domain.Party, error)
+	CancelSeries(seriesId uint64) ([]domain.Party, error)
+	CountByImage(image string) (uint64, error)
 }
 
 type partyRepository struct {
@@ -35,27 +53,15 @@ func NewPartyRepository(db *sql.DB) PartyRepository {
 }
 
 func (p partyRepository) FindById(id uint64) (domain.Party, error) {
-	partyModel := party{}
-	sqlCommand := `SELECT id, title, description, image, price, start_date, creator_id FROM parties WHERE id = $1;`
-	err := p.db.QueryRow(
-		sqlCommand,
-		id,
-	).Scan(
-		&partyModel.Id,
-		&partyModel.Title,
-		&partyModel.Description,
-		&partyModel.Image,
-		&partyModel.Price,
-		&partyModel.StartDate,
-		&partyModel.CreatorId,
-	)
+	sqlCommand := `SELECT ` + partyColumns + ` FROM parties WHERE id = $1;`
+	partyModel, err := p.scan(p.db.QueryRow(sqlCommand, id))
 	if err != nil {
 		return domain.Party{}, err
 	}
 	return p.modelToDomain(partyModel), nil
 }
 
-func (p partyRepository) FindByCreatorId(creatorId uint64, page, limit int32) (domain.Parties, error) {
+func (p partyRepository) FindByCreatorId(creatorId uint64, filter domain.PartyFilter, page, limit int32) (domain.Parties, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -67,25 +73,17 @@ func (p partyRepository) FindByCreatorId(creatorId uint64, page, limit int32) (d
 
 	var parties []domain.Party
 
-	sqlCommand := `SELECT id, title, description, image, price, start_date, creator_id FROM parties 
-	WHERE creator_id = $1 ORDER BY created_date DESC LIMIT $2 OFFSET $3;`
-	rows, err := p.db.Query(sqlCommand, creatorId, limit, offset)
+	where := `WHERE creator_id = $1 AND ` + partyFilterCondition(2)
+
+	sqlCommand := `SELECT ` + partyColumns + ` FROM parties ` + where + ` ORDER BY created_date DESC LIMIT $4 OFFSET $5;`
+	rows, err := p.db.Query(sqlCommand, creatorId, filter.HappeningNow, filter.Upcoming, limit, offset)
 	if err != nil {
 		return domain.Parties{}, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		partyModel := party{}
-		err := rows.Scan(
-			&partyModel.Id,
-			&partyModel.Title,
-			&partyModel.Description,
-			&partyModel.Image,
-			&partyModel.Price,
-			&partyModel.StartDate,
-			&partyModel.CreatorId,
-		)
+		partyModel, err := p.scan(rows)
 		if err != nil {
 			return domain.Parties{}, err
 		}
@@ -93,8 +91,8 @@ func (p partyRepository) FindByCreatorId(creatorId uint64, page, limit int32) (d
 	}
 
 	var total uint64
-	totalSqlCommand := `SELECT COUNT(*) FROM parties WHERE creator_id = $1;`
-	err = p.db.QueryRow(totalSqlCommand, creatorId).Scan(&total)
+	totalSqlCommand := `SELECT COUNT(*) FROM parties ` + where
+	err = p.db.QueryRow(totalSqlCommand, creatorId, filter.HappeningNow, filter.Upcoming).Scan(&total)
 	if err != nil {
 		return domain.Parties{}, err
 	}
@@ -111,6 +109,31 @@ func (p partyRepository) FindByCreatorId(creatorId uint64, page, limit int32) (d
 	}, nil
 }
 
+func (p partyRepository) FindJoinedOrHosted(userId uint64, from, to time.Time) ([]domain.Party, error) {
+	sqlCommand := `SELECT ` + partyColumns + ` FROM parties
+	WHERE (creator_id = $1 OR id IN (SELECT party_id FROM party_users WHERE user_id = $1))
+	AND end_date >= $2 AND start_date < $3
+	ORDER BY start_date`
+	rows, err := p.db.Query(sqlCommand, userId, from, to)
+	if err != nil {
+		return []domain.Party{}, err
+	}
+	defer rows.Close()
+
+	parties := []domain.Party{}
+	for rows.Next() {
+		partyModel, err := p.scan(rows)
+		if err != nil {
+			return []domain.Party{}, err
+		}
+		parties = append(parties, p.modelToDomain(partyModel))
+	}
+	if err = rows.Err(); err != nil {
+		return []domain.Party{}, err
+	}
+	return parties, nil
+}
+
 func (p partyRepository) FindPartiesByLikerId(likerId uint64, page, limit int32) (domain.Parties, error) {
 	if page < 1 {
 		page = 1
@@ -121,9 +144,8 @@ func (p partyRepository) FindPartiesByLikerId(likerId uint64, page, limit int32)
 
 	offset := (page - 1) * limit
 
-	sqlCommand := `select distinct 
-	parties.id, parties.title, parties.description, parties.image, parties.price, parties.start_date, parties.creator_id, parties.created_date
-	from likes inner join parties on parties.creator_id = likes.liked_id where likes.liker_id = $1 order by parties.created_date desc LIMIT $2 OFFSET $3;`
+	sqlCommand := `SELECT ` + partyColumns + ` FROM parties
+	WHERE creator_id IN (SELECT liked_id FROM likes WHERE liker_id = $1) ORDER BY created_date DESC LIMIT $2 OFFSET $3;`
 	rows, err := p.db.Query(sqlCommand, likerId, limit, offset)
 	if err != nil {
 		return domain.Parties{}, err
@@ -131,23 +153,12 @@ func (p partyRepository) FindPartiesByLikerId(likerId uint64, page, limit int32)
 
 	defer rows.Close()
 	var parties []domain.Party
-	var cork any
 	for rows.Next() {
-		var party party
-		err := rows.Scan(
-			&party.Id,
-			&party.Title,
-			&party.Description,
-			&party.Image,
-			&party.Price,
-			&party.StartDate,
-			&party.CreatorId,
-			&cork,
-		)
+		partyModel, err := p.scan(rows)
 		if err != nil {
 			return domain.Parties{}, err
 		}
-		parties = append(parties, p.modelToDomain(party))
+		parties = append(parties, p.modelToDomain(partyModel))
 	}
 
 	var total uint64
@@ -170,7 +181,7 @@ func (p partyRepository) FindPartiesByLikerId(likerId uint64, page, limit int32)
 	}, nil
 }
 
-func (p partyRepository) GetParties(page, limit int32) (domain.Parties, error) {
+func (p partyRepository) GetParties(filter domain.PartyFilter, page, limit int32) (domain.Parties, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -180,8 +191,10 @@ func (p partyRepository) GetParties(page, limit int32) (domain.Parties, error) {
 
 	offset := (page - 1) * limit
 
-	sqlCommand := `SELECT id, title, description, image, price, start_date, creator_id FROM parties ORDER BY created_date DESC LIMIT $1 OFFSET $2`
-	rows, err := p.db.Query(sqlCommand, limit, offset)
+	where := `WHERE ` + partyFilterCondition(1)
+
+	sqlCommand := `SELECT ` + partyColumns + ` FROM parties ` + where + ` ORDER BY created_date DESC LIMIT $3 OFFSET $4`
+	rows, err := p.db.Query(sqlCommand, filter.HappeningNow, filter.Upcoming, limit, offset)
 	if err != nil {
 		return domain.Parties{}, err
 	}
@@ -189,24 +202,15 @@ func (p partyRepository) GetParties(page, limit int32) (domain.Parties, error) {
 
 	var parties []domain.Party
 	for rows.Next() {
-		var party party
-		err := rows.Scan(
-			&party.Id,
-			&party.Title,
-			&party.Description,
-			&party.Image,
-			&party.Price,
-			&party.StartDate,
-			&party.CreatorId,
-		)
+		partyModel, err := p.scan(rows)
 		if err != nil {
 			return domain.Parties{}, err
 		}
-		parties = append(parties, p.modelToDomain(party))
+		parties = append(parties, p.modelToDomain(partyModel))
 	}
 	var total uint64
-	totalSqlCommand := `SELECT COUNT(*) FROM parties;`
-	err = p.db.QueryRow(totalSqlCommand).Scan(&total)
+	totalSqlCommand := `SELECT COUNT(*) FROM parties ` + where
+	err = p.db.QueryRow(totalSqlCommand, filter.HappeningNow, filter.Upcoming).Scan(&total)
 	if err != nil {
 		return domain.Parties{}, err
 	}
@@ -223,32 +227,85 @@ func (p partyRepository) GetParties(page, limit int32) (domain.Parties, error) {
 	}, nil
 }
 
+func partyFilterCondition(first int) string {
+	return fmt.Sprintf(
+		`($%d = false OR (start_date <= NOW() AND end_date > NOW())) AND ($%d = false OR start_date > NOW())`,
+		first, first+1,
+	)
+}
+
 func (p partyRepository) Save(party domain.Party) (domain.Party, error) {
+	var createdParty domain.Party
+	err := withTransaction(p.db, func(tx *sql.Tx) error {
+		var err error
+		createdParty, err = p.insert(tx, party)
+		return err
+	})
+	if err != nil {
+		return domain.Party{}, err
+	}
+	return createdParty, nil
+}
+
+func (p partyRepository) insert(tx *sql.Tx, party domain.Party) (domain.Party, error) {
 	partyModel := p.domainToModel(party)
 
 	sqlCommand := `INSERT INTO parties(
                   title, 
                   description, 
                   image, 
+                  image_medium,
+                  image_thumbnail,
                   price, 
                   start_date, 
-                  creator_id
-			  ) VALUES($1, $2, $3, $4, $5, $6) RETURNING id`
+                  end_date,
+                  timezone,
+                  creator_id,
+                  series_id
+			  ) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, created_date, updated_date`
 
-	err := p.db.QueryRow(
+	err := tx.QueryRow(
 		sqlCommand,
 		partyModel.Title,
 		partyModel.Description,
 		partyModel.Image,
+		partyModel.ImageMedium,
+		partyModel.ImageThumbnail,
 		partyModel.Price,
 		partyModel.StartDate,
+		partyModel.EndDate,
+		partyModel.Timezone,
 		partyModel.CreatorId,
-	).Scan(&partyModel.Id)
+		partyModel.SeriesId,
+	).Scan(&partyModel.Id, &partyModel.CreatedDate, &partyModel.UpdatedDate)
 	if err != nil {
 		return domain.Party{}, err
 	}
 
-	return p.modelToDomain(partyModel), nil
+	err = saveCoverImage(tx, p.modelToDomain(partyModel))
+	if err != nil {
+		return domain.Party{}, err
+	}
+
+	var tiers []domain.TicketTier
+	for _, tier := range party.Tiers {
+		tierModel := ticketTierDomainToModel(tier)
+		tierModel.PartyId = partyModel.Id
+		err = saveTicketTier(tx, &tierModel)
+		if err != nil {
+			return domain.Party{}, err
+		}
+		tiers = append(tiers, ticketTierModelToDomain(tierModel))
+	}
+
+	err = saveEvent(tx, domain.NewPartyEvent(domain.PartyCreatedEvent, p.modelToDomain(partyModel)))
+	if err != nil {
+		return domain.Party{}, err
+	}
+
+	createdParty := p.modelToDomain(partyModel)
+	createdParty.Tiers = tiers
+	return createdParty, nil
 }
 
 func (p partyRepository) Update(party domain.Party) (domain.Party, error) {
@@ -257,57 +314,318 @@ func (p partyRepository) Update(party domain.Party) (domain.Party, error) {
                  title = $1,
                  description = $2,
                  image = $3,
-                 start_date = $4 WHERE id = $5`
+                 image_medium = $4,
+                 image_thumbnail = $5,
+                 start_date = $6,
+                 end_date = $7,
+                 timezone = $8,
+                 updated_date = NOW() WHERE id = $9
+                 RETURNING ` + partyColumns
+
+	err := withTransaction(p.db, func(tx *sql.Tx) error {
+		var err error
+		partyModel, err = p.scan(tx.QueryRow(
+			sqlCommand,
+			partyModel.Title,
+			partyModel.Description,
+			partyModel.Image,
+			partyModel.ImageMedium,
+			partyModel.ImageThumbnail,
+			partyModel.StartDate,
+			partyModel.EndDate,
+			partyModel.Timezone,
+			partyModel.Id,
+		))
+		if err != nil {
+			return err
+		}
 
-	_, err := p.db.Exec(
-		sqlCommand,
-		partyModel.Title,
-		partyModel.Description,
-		partyModel.Image,
-		partyModel.StartDate,
-		partyModel.Id,
-	)
+		err = saveCoverImage(tx, p.modelToDomain(partyModel))
+		if err != nil {
+			return err
+		}
+
+		return saveEvent(tx, domain.NewPartyEvent(domain.PartyUpdatedEvent, p.modelToDomain(partyModel)))
+	})
 	if err != nil {
 		return domain.Party{}, err
 	}
 
-	newParty, err := p.FindById(partyModel.Id)
+	return p.modelToDomain(partyModel), nil
+}
+
+func (p partyRepository) Delete(id uint64) error {
+	sqlCommand := `SELECT ` + partyColumns + ` FROM parties WHERE id = $1 FOR UPDATE`
+
+	return withTransaction(p.db, func(tx *sql.Tx) error {
+		partyModel, err := p.scan(tx.QueryRow(sqlCommand, id))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = removeMembers(tx, partyModel.Id, partyModel.CreatorId)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`DELETE FROM parties WHERE id = $1`, partyModel.Id)
+		if err != nil {
+			return err
+		}
+
+		return saveEvent(tx, domain.NewPartyEvent(domain.PartyDeletedEvent, p.modelToDomain(partyModel)))
+	})
+}
+
+func (p partyRepository) FindSeriesById(id uint64) (domain.PartySeries, error) {
+	series := domain.PartySeries{}
+	sqlCommand := `SELECT id, creator_id, rule, created_date FROM party_series WHERE id = $1`
+	err := p.db.QueryRow(sqlCommand, id).Scan(
+		&series.Id,
+		&series.CreatorId,
+		&series.Rule,
+		&series.CreatedDate,
+	)
 	if err != nil {
-		return domain.Party{}, err
+		return domain.PartySeries{}, err
 	}
 
-	return newParty, nil
+	sqlCommand = `SELECT ` + partyColumns + ` FROM parties WHERE series_id = $1 ORDER BY start_date`
+	rows, err := p.db.Query(sqlCommand, id)
+	if err != nil {
+		return domain.PartySeries{}, err
+	}
+	defer rows.Close()
+
+	series.Parties, err = p.collect(rows)
+	if err != nil {
+		return domain.PartySeries{}, err
+	}
+	return series, nil
 }
 
-func (p partyRepository) Delete(id uint64) error {
-	sqlCommand := `DELETE FROM parties WHERE id = $1`
-	_, err := p.db.Exec(sqlCommand, id)
+func (p partyRepository) SaveSeries(series domain.PartySeries, parties []domain.Party, cost int32) (domain.PartySeries, error) {
+	sqlCommand := `INSERT INTO party_series (creator_id, rule) VALUES ($1, $2) RETURNING id, created_date`
+	err := withTransaction(p.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(sqlCommand, series.CreatorId, series.Rule).Scan(&series.Id, &series.CreatedDate)
+		if err != nil {
+			return err
+		}
+
+		err = applyPointTransaction(tx, &pointTransaction{
+			UserId: series.CreatorId,
+			Amount: -cost,
+			Type:   domain.PointTransactionPartySeries,
+			Reason: fmt.Sprintf("party series #%d", series.Id),
+		})
+		if err != nil {
+			return err
+		}
+
+		series.Parties = make([]domain.Party, len(parties))
+		for i, party := range parties {
+			party.SeriesId = &series.Id
+			series.Parties[i], err = p.insert(tx, party)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return domain.PartySeries{}, err
+	}
+	return series, nil
+}
+
+func (p partyRepository) UpdateSeries(party domain.Party, shift time.Duration, from *time.Time) ([]domain.Party, error) {
+	partyModel := p.domainToModel(party)
+	sqlCommand := `UPDATE parties SET
+	title = $1,
+	description = $2,
+	image = $3,
+	image_medium = $4,
+	image_thumbnail = $5,
+	start_date = start_date + make_interval(secs => $6),
+	end_date = start_date + make_interval(secs => $6 + $7),
+	timezone = $8,
+	updated_date = NOW()
+	WHERE series_id = $9 AND ($10::timestamptz IS NULL OR start_date >= $10)
+	RETURNING ` + partyColumns
+
+	var fromDate sql.NullTime
+	if from != nil {
+		fromDate = sql.NullTime{Time: *from, Valid: true}
+	}
+
+	var parties []domain.Party
+	err := withTransaction(p.db, func(tx *sql.Tx) error {
+		rows, err := tx.Query(
+			sqlCommand,
+			partyModel.Title,
+			partyModel.Description,
+			partyModel.Image,
+			partyModel.ImageMedium,
+			partyModel.ImageThumbnail,
+			shift.Seconds(),
+			party.Duration().Seconds(),
+			partyModel.Timezone,
+			partyModel.SeriesId,
+			fromDate,
+		)
+		if err != nil {
+			return err
+		}
+		parties, err = p.collect(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, updatedParty := range parties {
+			err = saveCoverImage(tx, updatedParty)
+			if err != nil {
+				return err
+			}
+			err = saveEvent(tx, domain.NewPartyEvent(domain.PartyUpdatedEvent, updatedParty))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []domain.Party{}, err
 	}
-	return nil
+	return parties, nil
+}
+
+func (p partyRepository) CancelSeries(seriesId uint64) ([]domain.Party, error) {
+	sqlCommand := `SELECT ` + partyColumns + ` FROM parties WHERE series_id = $1 AND start_date > NOW() FOR UPDATE`
+
+	var parties []domain.Party
+	err := withTransaction(p.db, func(tx *sql.Tx) error {
+		rows, err := tx.Query(sqlCommand, seriesId)
+		if err != nil {
+			return err
+		}
+		parties, err = p.collect(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, deletedParty := range parties {
+			err = removeMembers(tx, deletedParty.Id, deletedParty.CreatorId)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(`DELETE FROM parties WHERE id = $1`, deletedParty.Id)
+			if err != nil {
+				return err
+			}
+			err = saveEvent(tx, domain.NewPartyEvent(domain.PartyDeletedEvent, deletedParty))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []domain.Party{}, err
+	}
+	return parties, nil
+}
+
+func (p partyRepository) CountByImage(image string) (uint64, error) {
+	var count uint64
+	sqlCommand := `SELECT (SELECT COUNT(*) FROM parties WHERE image = $1) + (SELECT COUNT(*) FROM party_images WHERE url = $1)`
+	err := p.db.QueryRow(sqlCommand, image).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (p partyRepository) collect(rows *sql.Rows) ([]domain.Party, error) {
+	parties := []domain.Party{}
+	for rows.Next() {
+		partyModel, err := p.scan(rows)
+		if err != nil {
+			return []domain.Party{}, err
+		}
+		parties = append(parties, p.modelToDomain(partyModel))
+	}
+	return parties, rows.Err()
+}
+
+func (p partyRepository) scan(row interface{ Scan(dest ...any) error }) (party, error) {
+	partyModel := party{}
+	err := row.Scan(
+		&partyModel.Id,
+		&partyModel.Title,
+		&partyModel.Description,
+		&partyModel.Image,
+		&partyModel.ImageMedium,
+		&partyModel.ImageThumbnail,
+		&partyModel.Price,
+		&partyModel.StartDate,
+		&partyModel.EndDate,
+		&partyModel.Timezone,
+		&partyModel.CreatorId,
+		&partyModel.TipsTotal,
+		&partyModel.SeriesId,
+		&partyModel.CreatedDate,
+		&partyModel.UpdatedDate,
+	)
+	return partyModel, err
 }
 
 func (p partyRepository) domainToModel(domainParty domain.Party) party {
-	return party{
-		Id:          domainParty.Id,
-		Title:       domainParty.Title,
-		Description: domainParty.Description,
-		Image:       domainParty.Image,
-		Price:       domainParty.Price,
-		StartDate:   domainParty.StartDate,
-		CreatorId:   domainParty.CreatorId,
+	model := party{
+		Id:             domainParty.Id,
+		Title:          domainParty.Title,
+		Description:    domainParty.Description,
+		Image:          domainParty.Image,
+		ImageMedium:    domainParty.ImageMedium,
+		ImageThumbnail: domainParty.ImageThumbnail,
+		Price:          domainParty.Price,
+		StartDate:      domainParty.StartDate,
+		EndDate:        domainParty.EndDate,
+		Timezone:       domainParty.Timezone,
+		CreatorId:      domainParty.CreatorId,
+		TipsTotal:      domainParty.TipsTotal,
+		CreatedDate:    domainParty.CreatedDate,
+		UpdatedDate:    domainParty.UpdatedDate,
 	}
+	if domainParty.SeriesId != nil {
+		model.SeriesId = sql.NullInt64{Int64: int64(*domainParty.SeriesId), Valid: true}
+	}
+	return model
 }
 
 func (p partyRepository) modelToDomain(modelParty party) domain.Party {
-	return domain.Party{
-		Id:          modelParty.Id,
-		Title:       modelParty.Title,
-		Description: modelParty.Description,
-		Image:       modelParty.Image,
-		Price:       modelParty.Price,
-		StartDate:   modelParty.StartDate,
-		CreatorId:   modelParty.CreatorId,
+	domainParty := domain.Party{
+		Id:             modelParty.Id,
+		Title:          modelParty.Title,
+		Description:    modelParty.Description,
+		Image:          modelParty.Image,
+		ImageMedium:    modelParty.ImageMedium,
+		ImageThumbnail: modelParty.ImageThumbnail,
+		Price:          modelParty.Price,
+		StartDate:      modelParty.StartDate,
+		EndDate:        modelParty.EndDate,
+		Timezone:       modelParty.Timezone,
+		CreatorId:      modelParty.CreatorId,
+		TipsTotal:      modelParty.TipsTotal,
+		CreatedDate:    modelParty.CreatedDate,
+		UpdatedDate:    modelParty.UpdatedDate,
+	}
+	if modelParty.SeriesId.Valid {
+		seriesId := uint64(modelParty.SeriesId.Int64)
+		domainParty.SeriesId = &seriesId
 	}
+	return domainParty
 }
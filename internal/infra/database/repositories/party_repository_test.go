@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"testing"
+	"time"
+)
+
+type partyRefundTest struct {
+	db      *sql.DB
+	creator domain.User
+	member  domain.User
+}
+
+func newPartyRefundTest(t *testing.T) partyRefundTest {
+	db := testDatabase(t)
+	return partyRefundTest{
+		db:      db,
+		creator: createTestUser(t, db, 0),
+		member:  createTestUser(t, db, 100),
+	}
+}
+
+func (p partyRefundTest) party(start time.Time) domain.Party {
+	return domain.Party{
+		Title:     "Party",
+		Price:     30,
+		StartDate: start,
+		EndDate:   start.Add(4 * time.Hour),
+		Timezone:  "UTC",
+		CreatorId: p.creator.Id,
+	}
+}
+
+func (p partyRefundTest) join(t *testing.T, party domain.Party) {
+	err := NewMemberRepository(p.db).Save(domain.Member{PartyId: party.Id, UserId: p.member.Id, Price: party.Price}, nil)
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+}
+
+func (p partyRefundTest) assertRefunded(t *testing.T, partyIds ...uint64) {
+	if points := testUserPoints(t, p.db, p.member.Id); points != 100 {
+		t.Fatalf("member points = %d, want 100", points)
+	}
+	if points := testUserPoints(t, p.db, p.creator.Id); points != 0 {
+		t.Fatalf("creator points = %d, want 0", points)
+	}
+	for _, partyId := range partyIds {
+		members, err := NewMemberRepository(p.db).FindByPartyId(partyId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(members) != 0 {
+			t.Fatalf("party %d members = %+v, want none", partyId, members)
+		}
+	}
+	if count := countMemberLeftEvents(t, p.db, p.creator.Id); count != len(partyIds) {
+		t.Fatalf("member.left events = %d, want %d", count, len(partyIds))
+	}
+}
+
+func TestPartyDeleteRefundsMembers(t *testing.T) {
+	p := newPartyRefundTest(t)
+	partyRepo := NewPartyRepository(p.db)
+
+	party, err := partyRepo.Save(p.party(time.Now().Add(24 * time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.join(t, party)
+	if points := testUserPoints(t, p.db, p.creator.Id); points != 30 {
+		t.Fatalf("creator points after join = %d, want 30", points)
+	}
+
+	err = partyRepo.Delete(party.Id)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	p.assertRefunded(t, party.Id)
+}
+
+func TestCancelSeriesRefundsMembers(t *testing.T) {
+	p := newPartyRefundTest(t)
+	partyRepo := NewPartyRepository(p.db)
+
+	_, err := p.db.Exec(`UPDATE users SET points = 20 WHERE id = $1`, p.creator.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(24 * time.Hour)
+	series, err := partyRepo.SaveSeries(
+		domain.PartySeries{CreatorId: p.creator.Id, Rule: "FREQ=WEEKLY;COUNT=2"},
+		[]domain.Party{p.party(start), p.party(start.AddDate(0, 0, 7))},
+		20,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.join(t, series.Parties[0])
+
+	cancelled, err := partyRepo.CancelSeries(series.Id)
+	if err != nil {
+		t.Fatalf("CancelSeries() error = %v", err)
+	}
+	if len(cancelled) != 2 {
+		t.Fatalf("CancelSeries() = %d parties, want 2", len(cancelled))
+	}
+	p.assertRefunded(t, series.Parties[0].Id)
+}
+
+func TestSaveSeriesChargesTheCreatorInTheSameTransaction(t *testing.T) {
+	p := newPartyRefundTest(t)
+	partyRepo := NewPartyRepository(p.db)
+	start := time.Now().Add(24 * time.Hour)
+	parties := []domain.Party{p.party(start), p.party(start.AddDate(0, 0, 7))}
+	for i := range parties {
+		parties[i].CreatorId = p.member.Id
+	}
+
+	_, err := partyRepo.SaveSeries(domain.PartySeries{CreatorId: p.member.Id, Rule: "FREQ=WEEKLY;COUNT=2"}, parties, 101)
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("SaveSeries() error = %v, want %v", err, ErrInsufficientFunds)
+	}
+	var count int
+	err = p.db.QueryRow(`SELECT COUNT(*) FROM parties WHERE creator_id = $1`, p.member.Id).Scan(&count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("parties = %d, want none after a failed charge", count)
+	}
+
+	_, err = partyRepo.SaveSeries(domain.PartySeries{CreatorId: p.member.Id, Rule: "FREQ=WEEKLY;COUNT=2"}, parties, 60)
+	if err != nil {
+		t.Fatalf("SaveSeries() error = %v", err)
+	}
+	if points := testUserPoints(t, p.db, p.member.Id); points != 40 {
+		t.Fatalf("creator points = %d, want 40", points)
+	}
+}
+
+func TestFindJoinedOrHostedReturnsPartiesInTheWindow(t *testing.T) {
+	p := newPartyRefundTest(t)
+	partyRepo := NewPartyRepository(p.db)
+	now := time.Now()
+
+	save := func(creatorId uint64, start time.Time) domain.Party {
+		party := p.party(start)
+		party.CreatorId = creatorId
+		party, err := partyRepo.Save(party)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return party
+	}
+	hosted := save(p.member.Id, now.Add(48*time.Hour))
+	joined := save(p.creator.Id, now.Add(24*time.Hour))
+	p.join(t, joined)
+	save(p.creator.Id, now.Add(36*time.Hour))
+	save(p.member.Id, now.Add(30*24*time.Hour))
+
+	parties, err := partyRepo.FindJoinedOrHosted(p.member.Id, now, now.Add(7*24*time.Hour))
+	if err != nil {
+		t.Fatalf("FindJoinedOrHosted() error = %v", err)
+	}
+	if len(parties) != 2 || parties[0].Id != joined.Id || parties[1].Id != hosted.Id {
+		t.Fatalf("FindJoinedOrHosted() = %+v, want parties %d and %d", parties, joined.Id, hosted.Id)
+	}
+}
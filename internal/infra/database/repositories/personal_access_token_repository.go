@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const personalAccessTokenColumns = `id, user_id, name, token_hash, prefix, scopes, expires_date, last_used_date, created_date`
+
+type personalAccessToken struct {
+	Id           uint64       `db:"id, omitempty"`
+	UserId       uint64       `db:"user_id"`
+	Name         string       `db:"name"`
+	TokenHash    string       `db:"token_hash"`
+	Prefix       string       `db:"prefix"`
+	Scopes       []string     `db:"scopes"`
+	ExpiresDate  sql.NullTime `db:"expires_date"`
+	LastUsedDate sql.NullTime `db:"last_used_date"`
+	CreatedDate  time.Time    `db:"created_date"`
+}
+
+type PersonalAccessTokenRepository interface {
+	FindById(id uint64) (domain.PersonalAccessToken, error)
+	FindByUserId(userId uint64) ([]domain.PersonalAccessToken, error)
+	FindByHash(tokenHash string) (domain.PersonalAccessToken, error)
+	Save(token domain.PersonalAccessToken) (domain.PersonalAccessToken, error)
+	Touch(id uint64) error
+	Delete(id uint64) error
+}
+
+type personalAccessTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPersonalAccessTokenRepository(db *sql.DB) PersonalAccessTokenRepository {
+	return personalAccessTokenRepository{db: db}
+}
+
+func (pr personalAccessTokenRepository) FindById(id uint64) (domain.PersonalAccessToken, error) {
+	sqlCommand := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens WHERE id = $1`
+	tokenModel, err := pr.scan(pr.db.QueryRow(sqlCommand, id))
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	return pr.modelToDomain(tokenModel), nil
+}
+
+func (pr personalAccessTokenRepository) FindByUserId(userId uint64) ([]domain.PersonalAccessToken, error) {
+	sqlCommand := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens WHERE user_id = $1 ORDER BY id`
+	rows, err := pr.db.Query(sqlCommand, userId)
+	if err != nil {
+		return []domain.PersonalAccessToken{}, err
+	}
+	defer rows.Close()
+
+	tokens := []domain.PersonalAccessToken{}
+	for rows.Next() {
+		tokenModel, err := pr.scan(rows)
+		if err != nil {
+			return []domain.PersonalAccessToken{}, err
+		}
+		tokens = append(tokens, pr.modelToDomain(tokenModel))
+	}
+	return tokens, rows.Err()
+}
+
+func (pr personalAccessTokenRepository) FindByHash(tokenHash string) (domain.PersonalAccessToken, error) {
+	sqlCommand := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens WHERE token_hash = $1`
+	tokenModel, err := pr.scan(pr.db.QueryRow(sqlCommand, tokenHash))
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	return pr.modelToDomain(tokenModel), nil
+}
+
+func (pr personalAccessTokenRepository) Save(token domain.PersonalAccessToken) (domain.PersonalAccessToken, error) {
+	tokenModel := pr.domainToModel(token)
+	sqlCommand := `INSERT INTO personal_access_tokens (user_id, name, token_hash, prefix, scopes, expires_date)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_date`
+	err := pr.db.QueryRow(
+		sqlCommand,
+		tokenModel.UserId,
+		tokenModel.Name,
+		tokenModel.TokenHash,
+		tokenModel.Prefix,
+		pq.Array(tokenModel.Scopes),
+		tokenModel.ExpiresDate,
+	).Scan(
+		&tokenModel.Id,
+		&tokenModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	return pr.modelToDomain(tokenModel), nil
+}
+
+func (pr personalAccessTokenRepository) Touch(id uint64) error {
+	sqlCommand := `UPDATE personal_access_tokens SET last_used_date = NOW() WHERE id = $1`
+	_, err := pr.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr personalAccessTokenRepository) Delete(id uint64) error {
+	sqlCommand := `DELETE FROM personal_access_tokens WHERE id = $1`
+	_, err := pr.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr personalAccessTokenRepository) scan(row interface{ Scan(dest ...any) error }) (personalAccessToken, error) {
+	tokenModel := personalAccessToken{}
+	err := row.Scan(
+		&tokenModel.Id,
+		&tokenModel.UserId,
+		&tokenModel.Name,
+		&tokenModel.TokenHash,
+		&tokenModel.Prefix,
+		pq.Array(&tokenModel.Scopes),
+		&tokenModel.ExpiresDate,
+		&tokenModel.LastUsedDate,
+		&tokenModel.CreatedDate,
+	)
+	return tokenModel, err
+}
+
+func (pr personalAccessTokenRepository) domainToModel(t domain.PersonalAccessToken) personalAccessToken {
+	model := personalAccessToken{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		Name:        t.Name,
+		TokenHash:   t.TokenHash,
+		Prefix:      t.Prefix,
+		Scopes:      t.Scopes,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.ExpiresDate != nil {
+		model.ExpiresDate = sql.NullTime{Time: *t.ExpiresDate, Valid: true}
+	}
+	if t.LastUsedDate != nil {
+		model.LastUsedDate = sql.NullTime{Time: *t.LastUsedDate, Valid: true}
+	}
+	return model
+}
+
+func (pr personalAccessTokenRepository) modelToDomain(t personalAccessToken) domain.PersonalAccessToken {
+	token := domain.PersonalAccessToken{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		Name:        t.Name,
+		TokenHash:   t.TokenHash,
+		Prefix:      t.Prefix,
+		Scopes:      t.Scopes,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.ExpiresDate.Valid {
+		token.ExpiresDate = &t.ExpiresDate.Time
+	}
+	if t.LastUsedDate.Valid {
+		token.LastUsedDate = &t.LastUsedDate.Time
+	}
+	return token
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+type pointTransaction struct {
+	Id          uint64        `db:"id, omitempty"`
+	UserId      uint64        `db:"user_id"`
+	Amount      int32         `db:"amount"`
+	Balance     int32         `db:"balance"`
+	Type        string        `db:"type"`
+	Reason      string        `db:"reason"`
+	ActorId     sql.NullInt64 `db:"actor_id"`
+	CreatedDate time.Time     `db:"created_date"`
+}
+
+type PointTransactionRepository interface {
+	Save(transaction domain.PointTransaction) (domain.PointTransaction, error)
+}
+
+type pointTransactionRepository struct {
+	db *sql.DB
+}
+
+func NewPointTransactionRepository(db *sql.DB) PointTransactionRepository {
+	return pointTransactionRepository{db: db}
+}
+
+func (pt pointTransactionRepository) Save(transaction domain.PointTransaction) (domain.PointTransaction, error) {
+	transactionModel := pt.domainToModel(transaction)
+	err := withTransaction(pt.db, func(tx *sql.Tx) error {
+		return applyPointTransaction(tx, &transactionModel)
+	})
+	if err != nil {
+		return domain.PointTransaction{}, err
+	}
+	return pt.modelToDomain(transactionModel), nil
+}
+
+func applyPointTransaction(tx *sql.Tx, transactionModel *pointTransaction) error {
+	sqlCommand := `UPDATE users SET points = points + $1 WHERE id = $2 AND points + $1 >= 0 RETURNING points`
+	err := tx.QueryRow(sqlCommand, transactionModel.Amount, transactionModel.UserId).Scan(&transactionModel.Balance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInsufficientFunds
+		}
+		return err
+	}
+
+	sqlCommand = `INSERT INTO point_transactions (user_id, amount, balance, type, reason, actor_id)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_date`
+	return tx.QueryRow(
+		sqlCommand,
+		transactionModel.UserId,
+		transactionModel.Amount,
+		transactionModel.Balance,
+		transactionModel.Type,
+		transactionModel.Reason,
+		transactionModel.ActorId,
+	).Scan(
+		&transactionModel.Id,
+		&transactionModel.CreatedDate,
+	)
+}
+
+func (pt pointTransactionRepository) domainToModel(t domain.PointTransaction) pointTransaction {
+	model := pointTransaction{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		Amount:      t.Amount,
+		Balance:     t.Balance,
+		Type:        t.Type,
+		Reason:      t.Reason,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.ActorId != nil {
+		model.ActorId = sql.NullInt64{Int64: int64(*t.ActorId), Valid: true}
+	}
+	return model
+}
+
+func (pt pointTransactionRepository) modelToDomain(t pointTransaction) domain.PointTransaction {
+	transaction := domain.PointTransaction{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		Amount:      t.Amount,
+		Balance:     t.Balance,
+		Type:        t.Type,
+		Reason:      t.Reason,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.ActorId.Valid {
+		actorId := uint64(t.ActorId.Int64)
+		transaction.ActorId = &actorId
+	}
+	return transaction
+}
@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+var (
+	ErrPromoCodeExists      = errors.New("promo code already exists for this party")
+	ErrPromoCodeUnavailable = errors.New("promo code is expired or fully redeemed")
+	ErrPromoCodeRedeemed    = errors.New("promo code was already used")
+)
+
+const promoCodeColumns = `id, party_id, code, discount_type, discount_value, max_redemptions, redemptions, valid_from, valid_until, created_date`
+
+type promoCode struct {
+	Id             uint64        `db:"id, omitempty"`
+	PartyId        uint64        `db:"party_id"`
+	Code           string        `db:"code"`
+	DiscountType   string        `db:"discount_type"`
+	DiscountValue  int32         `db:"discount_value"`
+	MaxRedemptions sql.NullInt32 `db:"max_redemptions"`
+	Redemptions    int32         `db:"redemptions"`
+	ValidFrom      sql.NullTime  `db:"valid_from"`
+	ValidUntil     sql.NullTime  `db:"valid_until"`
+	CreatedDate    time.Time     `db:"created_date"`
+}
+
+type promoRedemption struct {
+	Id          uint64    `db:"id, omitempty"`
+	PromoCodeId uint64    `db:"promo_code_id"`
+	UserId      uint64    `db:"user_id"`
+	Price       int32     `db:"price"`
+	Discount    int32     `db:"discount"`
+	CreatedDate time.Time `db:"created_date"`
+}
+
+type PromoCodeRepository interface {
+	FindByPartyId(partyId uint64) ([]domain.PromoCode, error)
+	FindByCode(partyId uint64, code string) (domain.PromoCode, error)
+	FindRedemptions(partyId, promoCodeId uint64) ([]domain.PromoRedemption, error)
+	Save(promoCode domain.PromoCode) (domain.PromoCode, error)
+	Delete(partyId, id uint64) error
+}
+
+type promoCodeRepository struct {
+	db *sql.DB
+}
+
+func NewPromoCodeRepository(db *sql.DB) PromoCodeRepository {
+	return promoCodeRepository{db: db}
+}
+
+func (pr promoCodeRepository) FindByPartyId(partyId uint64) ([]domain.PromoCode, error) {
+	sqlCommand := `SELECT ` + promoCodeColumns + ` FROM promo_codes WHERE party_id = $1 ORDER BY id`
+	rows, err := pr.db.Query(sqlCommand, partyId)
+	if err != nil {
+		return []domain.PromoCode{}, err
+	}
+	defer rows.Close()
+
+	promoCodes := []domain.PromoCode{}
+	for rows.Next() {
+		promoCodeModel, err := pr.scan(rows)
+		if err != nil {
+			return []domain.PromoCode{}, err
+		}
+		promoCodes = append(promoCodes, pr.modelToDomain(promoCodeModel))
+	}
+	return promoCodes, rows.Err()
+}
+
+func (pr promoCodeRepository) FindByCode(partyId uint64, code string) (domain.PromoCode, error) {
+	sqlCommand := `SELECT ` + promoCodeColumns + ` FROM promo_codes WHERE party_id = $1 AND code = $2`
+	promoCodeModel, err := pr.scan(pr.db.QueryRow(sqlCommand, partyId, code))
+	if err != nil {
+		return domain.PromoCode{}, err
+	}
+	return pr.modelToDomain(promoCodeModel), nil
+}
+
+func (pr promoCodeRepository) FindRedemptions(partyId, promoCodeId uint64) ([]domain.PromoRedemption, error) {
+	sqlCommand := `SELECT r.id, r.promo_code_id, r.user_id, r.price, r.discount, r.created_date
+	FROM promo_redemptions r INNER JOIN promo_codes c ON c.id = r.promo_code_id
+	WHERE c.party_id = $1 AND r.promo_code_id = $2 ORDER BY r.id`
+	rows, err := pr.db.Query(sqlCommand, partyId, promoCodeId)
+	if err != nil {
+		return []domain.PromoRedemption{}, err
+	}
+	defer rows.Close()
+
+	redemptions := []domain.PromoRedemption{}
+	for rows.Next() {
+		redemptionModel := promoRedemption{}
+		err := rows.Scan(
+			&redemptionModel.Id,
+			&redemptionModel.PromoCodeId,
+			&redemptionModel.UserId,
+			&redemptionModel.Price,
+			&redemptionModel.Discount,
+			&redemptionModel.CreatedDate,
+		)
+		if err != nil {
+			return []domain.PromoRedemption{}, err
+		}
+		redemptions = append(redemptions, domain.PromoRedemption{
+			Id:          redemptionModel.Id,
+			PromoCodeId: redemptionModel.PromoCodeId,
+			UserId:      redemptionModel.UserId,
+			Price:       redemptionModel.Price,
+			Discount:    redemptionModel.Discount,
+			CreatedDate: redemptionModel.CreatedDate,
+		})
+	}
+	return redemptions, rows.Err()
+}
+
+func (pr promoCodeRepository) Save(promoCode domain.PromoCode) (domain.PromoCode, error) {
+	promoCodeModel := pr.domainToModel(promoCode)
+	sqlCommand := `INSERT INTO promo_codes (party_id, code, discount_type, discount_value, max_redemptions, valid_from, valid_until)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (party_id, code) DO NOTHING RETURNING id, redemptions, created_date`
+	err := pr.db.QueryRow(
+		sqlCommand,
+		promoCodeModel.PartyId,
+		promoCodeModel.Code,
+		promoCodeModel.DiscountType,
+		promoCodeModel.DiscountValue,
+		promoCodeModel.MaxRedemptions,
+		promoCodeModel.ValidFrom,
+		promoCodeModel.ValidUntil,
+	).Scan(
+		&promoCodeModel.Id,
+		&promoCodeModel.Redemptions,
+		&promoCodeModel.CreatedDate,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PromoCode{}, ErrPromoCodeExists
+		}
+		return domain.PromoCode{}, err
+	}
+	return pr.modelToDomain(promoCodeModel), nil
+}
+
+func (pr promoCodeRepository) Delete(partyId, id uint64) error {
+	sqlCommand := `DELETE FROM promo_codes WHERE id = $1 AND party_id = $2`
+	result, err := pr.db.Exec(sqlCommand, id, partyId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// The limits are checked again here so racing joiners can't both take the last use.
+func redeemPromoCode(tx *sql.Tx, redemption domain.PromoRedemption) error {
+	sqlCommand := `UPDATE promo_codes SET redemptions = redemptions + 1
+	WHERE id = $1
+	AND (max_redemptions IS NULL OR redemptions < max_redemptions)
+	AND (valid_from IS NULL OR valid_from <= NOW())
+	AND (valid_until IS NULL OR valid_until > NOW())`
+	result, err := tx.Exec(sqlCommand, redemption.PromoCodeId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrPromoCodeUnavailable
+	}
+
+	sqlCommand = `INSERT INTO promo_redemptions (promo_code_id, user_id, price, discount)
+	VALUES ($1, $2, $3, $4) ON CONFLICT (promo_code_id, user_id) DO NOTHING`
+	result, err = tx.Exec(sqlCommand, redemption.PromoCodeId, redemption.UserId, redemption.Price, redemption.Discount)
+	if err != nil {
+		return err
+	}
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrPromoCodeRedeemed
+	}
+	return nil
+}
+
+func releasePromoCode(tx *sql.Tx, partyId, userId uint64) error {
+	sqlCommand := `WITH removed AS (
+		DELETE FROM promo_redemptions USING promo_codes
+		WHERE promo_redemptions.promo_code_id = promo_codes.id AND promo_codes.party_id = $1 AND promo_redemptions.user_id = $2
+		RETURNING promo_redemptions.promo_code_id
+	)
+	UPDATE promo_codes SET redemptions = redemptions - 1 WHERE id IN (SELECT promo_code_id FROM removed) AND redemptions > 0`
+	_, err := tx.Exec(sqlCommand, partyId, userId)
+	return err
+}
+
+func (pr promoCodeRepository) scan(row interface{ Scan(dest ...any) error }) (promoCode, error) {
+	promoCodeModel := promoCode{}
+	err := row.Scan(
+		&promoCodeModel.Id,
+		&promoCodeModel.PartyId,
+		&promoCodeModel.Code,
+		&promoCodeModel.DiscountType,
+		&promoCodeModel.DiscountValue,
+		&promoCodeModel.MaxRedemptions,
+		&promoCodeModel.Redemptions,
+		&promoCodeModel.ValidFrom,
+		&promoCodeModel.ValidUntil,
+		&promoCodeModel.CreatedDate,
+	)
+	return promoCodeModel, err
+}
+
+func (pr promoCodeRepository) domainToModel(p domain.PromoCode) promoCode {
+	model := promoCode{
+		Id:            p.Id,
+		PartyId:       p.PartyId,
+		Code:          p.Code,
+		DiscountType:  p.DiscountType,
+		DiscountValue: p.DiscountValue,
+		Redemptions:   p.Redemptions,
+		CreatedDate:   p.CreatedDate,
+	}
+	if p.MaxRedemptions != nil {
+		model.MaxRedemptions = sql.NullInt32{Int32: *p.MaxRedemptions, Valid: true}
+	}
+	if p.ValidFrom != nil {
+		model.ValidFrom = sql.NullTime{Time: *p.ValidFrom, Valid: true}
+	}
+	if p.ValidUntil != nil {
+		model.ValidUntil = sql.NullTime{Time: *p.ValidUntil, Valid: true}
+	}
+	return model
+}
+
+func (pr promoCodeRepository) modelToDomain(p promoCode) domain.PromoCode {
+	domainPromoCode := domain.PromoCode{
+		Id:            p.Id,
+		PartyId:       p.PartyId,
+		Code:          p.Code,
+		DiscountType:  p.DiscountType,
+		DiscountValue: p.DiscountValue,
+		Redemptions:   p.Redemptions,
+		CreatedDate:   p.CreatedDate,
+	}
+	if p.MaxRedemptions.Valid {
+		domainPromoCode.MaxRedemptions = &p.MaxRedemptions.Int32
+	}
+	if p.ValidFrom.Valid {
+		domainPromoCode.ValidFrom = &p.ValidFrom.Time
+	}
+	if p.ValidUntil.Valid {
+		domainPromoCode.ValidUntil = &p.ValidUntil.Time
+	}
+	return domainPromoCode
+}
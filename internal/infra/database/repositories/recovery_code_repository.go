@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type RecoveryCodeRepository interface {
+	Replace(userId uint64, codeHashes []string) error
+	Use(userId uint64, codeHash string) error
+	DeleteByUserId(userId uint64) error
+}
+
+type recoveryCodeRepository struct {
+	db *sql.DB
+}
+
+func NewRecoveryCodeRepository(db *sql.DB) RecoveryCodeRepository {
+	return recoveryCodeRepository{db: db}
+}
+
+func (rc recoveryCodeRepository) Replace(userId uint64, codeHashes []string) error {
+	return withTransaction(rc.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id = $1`, userId)
+		if err != nil {
+			return err
+		}
+
+		sqlCommand := `INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`
+		for _, codeHash := range codeHashes {
+			_, err = tx.Exec(sqlCommand, userId, codeHash)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (rc recoveryCodeRepository) Use(userId uint64, codeHash string) error {
+	sqlCommand := `UPDATE recovery_codes SET used_date = NOW() 
+	WHERE user_id = $1 AND code_hash = $2 AND used_date IS NULL`
+	result, err := rc.db.Exec(sqlCommand, userId, codeHash)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("recovery code does not exist")
+	}
+	return nil
+}
+
+func (rc recoveryCodeRepository) DeleteByUserId(userId uint64) error {
+	sqlCommand := `DELETE FROM recovery_codes WHERE user_id = $1`
+	_, err := rc.db.Exec(sqlCommand, userId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
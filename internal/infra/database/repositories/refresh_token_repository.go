@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type refreshToken struct {
+	Id          uint64       `db:"id, omitempty"`
+	UserId      uint64       `db:"user_id"`
+	SessionUUID uuid.UUID    `db:"session_uuid"`
+	TokenHash   string       `db:"token_hash"`
+	ExpiresDate time.Time    `db:"expires_date"`
+	UsedDate    sql.NullTime `db:"used_date"`
+	CreatedDate time.Time    `db:"created_date"`
+}
+
+type RefreshTokenRepository interface {
+	Save(token domain.RefreshToken) (domain.RefreshToken, error)
+	FindByHash(tokenHash string) (domain.RefreshToken, error)
+	MarkUsed(id uint64) error
+}
+
+type refreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) RefreshTokenRepository {
+	return refreshTokenRepository{db: db}
+}
+
+func (rt refreshTokenRepository) Save(token domain.RefreshToken) (domain.RefreshToken, error) {
+	tokenModel := rt.domainToModel(token)
+	sqlCommand := `INSERT INTO refresh_tokens (user_id, session_uuid, token_hash, expires_date) 
+	VALUES ($1, $2, $3, $4) RETURNING id, created_date`
+	err := rt.db.QueryRow(
+		sqlCommand,
+		tokenModel.UserId,
+		tokenModel.SessionUUID,
+		tokenModel.TokenHash,
+		tokenModel.ExpiresDate,
+	).Scan(
+		&tokenModel.Id,
+		&tokenModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return rt.modelToDomain(tokenModel), nil
+}
+
+func (rt refreshTokenRepository) FindByHash(tokenHash string) (domain.RefreshToken, error) {
+	tokenModel := refreshToken{}
+	sqlCommand := `SELECT id, user_id, session_uuid, token_hash, expires_date, used_date, created_date 
+	FROM refresh_tokens WHERE token_hash = $1`
+	err := rt.db.QueryRow(sqlCommand, tokenHash).Scan(
+		&tokenModel.Id,
+		&tokenModel.UserId,
+		&tokenModel.SessionUUID,
+		&tokenModel.TokenHash,
+		&tokenModel.ExpiresDate,
+		&tokenModel.UsedDate,
+		&tokenModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return rt.modelToDomain(tokenModel), nil
+}
+
+func (rt refreshTokenRepository) MarkUsed(id uint64) error {
+	sqlCommand := `UPDATE refresh_tokens SET used_date = NOW() WHERE id = $1 AND used_date IS NULL`
+	result, err := rt.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("refresh token already used")
+	}
+	return nil
+}
+
+func (rt refreshTokenRepository) domainToModel(t domain.RefreshToken) refreshToken {
+	model := refreshToken{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		SessionUUID: t.SessionUUID,
+		TokenHash:   t.TokenHash,
+		ExpiresDate: t.ExpiresDate,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.UsedDate != nil {
+		model.UsedDate = sql.NullTime{Time: *t.UsedDate, Valid: true}
+	}
+	return model
+}
+
+func (rt refreshTokenRepository) modelToDomain(t refreshToken) domain.RefreshToken {
+	token := domain.RefreshToken{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		SessionUUID: t.SessionUUID,
+		TokenHash:   t.TokenHash,
+		ExpiresDate: t.ExpiresDate,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.UsedDate.Valid {
+		token.UsedDate = &t.UsedDate.Time
+	}
+	return token
+}
@@ -2,20 +2,28 @@ package repositories
 
 import (
 	"database/sql"
-	"errors"
 	"github.com/google/uuid"
 	"go-rest-api/internal/domain"
+	"time"
 )
 
 type session struct {
-	UserId uint64    `db:"user_id"`
-	UUID   uuid.UUID `db:"uuid"`
+	UserId       uint64    `db:"user_id"`
+	UUID         uuid.UUID `db:"uuid"`
+	CreatedDate  time.Time `db:"created_date"`
+	LastSeenDate time.Time `db:"last_seen_date"`
+	UserAgent    string    `db:"user_agent"`
+	Ip           string    `db:"ip"`
 }
 
 type SessionRepository interface {
-	Save(sess domain.Session) error
-	Exists(sess domain.Session) error
+	Save(sess domain.Session) (domain.Session, error)
+	Find(sess domain.Session) (domain.Session, error)
+	FindByUserId(userId uint64) ([]domain.Session, error)
+	Touch(sess domain.Session) error
 	Delete(sess domain.Session) error
+	DeleteByUserId(userId uint64) error
+	DeleteOthers(sess domain.Session) error
 }
 
 type sessionRepository struct {
@@ -26,26 +34,72 @@ func NewSessionRepository(db *sql.DB) SessionRepository {
 	return &sessionRepository{db: db}
 }
 
-func (sr sessionRepository) Save(sess domain.Session) error {
+func (sr sessionRepository) Save(sess domain.Session) (domain.Session, error) {
 	s := sr.domainToModel(sess)
-	sqlCommand := `INSERT INTO sessions (uuid, user_id) VALUES ($1, $2)`
-	_, err := sr.db.Exec(sqlCommand, s.UUID, s.UserId)
+	sqlCommand := `INSERT INTO sessions (uuid, user_id, user_agent, ip) VALUES ($1, $2, $3, $4) 
+	RETURNING created_date, last_seen_date`
+	err := sr.db.QueryRow(sqlCommand, s.UUID, s.UserId, s.UserAgent, s.Ip).Scan(
+		&s.CreatedDate,
+		&s.LastSeenDate,
+	)
 	if err != nil {
-		return err
+		return domain.Session{}, err
 	}
-	return nil
+	return sr.modelToDomain(s), nil
 }
 
-func (sr sessionRepository) Exists(sess domain.Session) error {
-	s := sr.domainToModel(sess)
-	sqlCommand := `SELECT * FROM sessions WHERE uuid = $1 AND user_id = $2`
-	rows, err := sr.db.Query(sqlCommand, s.UUID, sess.UserId)
+func (sr sessionRepository) Find(sess domain.Session) (domain.Session, error) {
+	s := session{}
+	sqlCommand := `SELECT user_id, uuid, created_date, last_seen_date, user_agent, ip FROM sessions 
+	WHERE uuid = $1 AND user_id = $2`
+	err := sr.db.QueryRow(sqlCommand, sess.UUID, sess.UserId).Scan(
+		&s.UserId,
+		&s.UUID,
+		&s.CreatedDate,
+		&s.LastSeenDate,
+		&s.UserAgent,
+		&s.Ip,
+	)
 	if err != nil {
-		return err
+		return domain.Session{}, err
+	}
+	return sr.modelToDomain(s), nil
+}
+
+func (sr sessionRepository) FindByUserId(userId uint64) ([]domain.Session, error) {
+	sqlCommand := `SELECT user_id, uuid, created_date, last_seen_date, user_agent, ip FROM sessions 
+	WHERE user_id = $1 ORDER BY last_seen_date DESC`
+	rows, err := sr.db.Query(sqlCommand, userId)
+	if err != nil {
+		return []domain.Session{}, err
 	}
 	defer rows.Close()
-	if !rows.Next() {
-		return errors.New("session does not exist")
+
+	sessions := []domain.Session{}
+	for rows.Next() {
+		s := session{}
+		err := rows.Scan(
+			&s.UserId,
+			&s.UUID,
+			&s.CreatedDate,
+			&s.LastSeenDate,
+			&s.UserAgent,
+			&s.Ip,
+		)
+		if err != nil {
+			return []domain.Session{}, err
+		}
+		sessions = append(sessions, sr.modelToDomain(s))
+	}
+	return sessions, nil
+}
+
+func (sr sessionRepository) Touch(sess domain.Session) error {
+	s := sr.domainToModel(sess)
+	sqlCommand := `UPDATE sessions SET last_seen_date = NOW(), user_agent = $1, ip = $2 WHERE uuid = $3 AND user_id = $4`
+	_, err := sr.db.Exec(sqlCommand, s.UserAgent, s.Ip, s.UUID, s.UserId)
+	if err != nil {
+		return err
 	}
 	return nil
 }
@@ -60,9 +114,43 @@ func (sr sessionRepository) Delete(sess domain.Session) error {
 	return nil
 }
 
+func (sr sessionRepository) DeleteByUserId(userId uint64) error {
+	sqlCommand := `DELETE FROM sessions WHERE user_id = $1`
+	_, err := sr.db.Exec(sqlCommand, userId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr sessionRepository) DeleteOthers(sess domain.Session) error {
+	s := sr.domainToModel(sess)
+	sqlCommand := `DELETE FROM sessions WHERE user_id = $1 AND uuid <> $2`
+	_, err := sr.db.Exec(sqlCommand, s.UserId, s.UUID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (sr sessionRepository) domainToModel(sess domain.Session) session {
 	return session{
-		UserId: sess.UserId,
-		UUID:   sess.UUID,
+		UserId:       sess.UserId,
+		UUID:         sess.UUID,
+		CreatedDate:  sess.CreatedDate,
+		LastSeenDate: sess.LastSeenDate,
+		UserAgent:    sess.UserAgent,
+		Ip:           sess.Ip,
+	}
+}
+
+func (sr sessionRepository) modelToDomain(s session) domain.Session {
+	return domain.Session{
+		UserId:       s.UserId,
+		UUID:         s.UUID,
+		CreatedDate:  s.CreatedDate,
+		LastSeenDate: s.LastSeenDate,
+		UserAgent:    s.UserAgent,
+		Ip:           s.Ip,
 	}
 }
@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+var (
+	ErrTierExists  = errors.New("ticket tier with this name already exists")
+	ErrTierSoldOut = errors.New("ticket tier is sold out")
+)
+
+const ticketTierColumns = `id, party_id, name, price, capacity, sold, created_date`
+
+type ticketTier struct {
+	Id          uint64    `db:"id, omitempty"`
+	PartyId     uint64    `db:"party_id"`
+	Name        string    `db:"name"`
+	Price       int32     `db:"price"`
+	Capacity    int32     `db:"capacity"`
+	Sold        int32     `db:"sold"`
+	CreatedDate time.Time `db:"created_date"`
+}
+
+type TicketTierRepository interface {
+	FindById(partyId, id uint64) (domain.TicketTier, error)
+	FindByPartyId(partyId uint64) ([]domain.TicketTier, error)
+	Save(tier domain.TicketTier) (domain.TicketTier, error)
+	Update(tier domain.TicketTier) (domain.TicketTier, error)
+	Delete(partyId, id uint64) error
+}
+
+type ticketTierRepository struct {
+	db *sql.DB
+}
+
+func NewTicketTierRepository(db *sql.DB) TicketTierRepository {
+	return ticketTierRepository{db: db}
+}
+
+func (tr ticketTierRepository) FindById(partyId, id uint64) (domain.TicketTier, error) {
+	sqlCommand := `SELECT ` + ticketTierColumns + ` FROM ticket_tiers WHERE id = $1 AND party_id = $2`
+	tierModel, err := scanTicketTier(tr.db.QueryRow(sqlCommand, id, partyId))
+	if err != nil {
+		return domain.TicketTier{}, err
+	}
+	return ticketTierModelToDomain(tierModel), nil
+}
+
+func (tr ticketTierRepository) FindByPartyId(partyId uint64) ([]domain.TicketTier, error) {
+	sqlCommand := `SELECT ` + ticketTierColumns + ` FROM ticket_tiers WHERE party_id = $1 ORDER BY price, id`
+	rows, err := tr.db.Query(sqlCommand, partyId)
+	if err != nil {
+		return []domain.TicketTier{}, err
+	}
+	defer rows.Close()
+
+	tiers := []domain.TicketTier{}
+	for rows.Next() {
+		tierModel, err := scanTicketTier(rows)
+		if err != nil {
+			return []domain.TicketTier{}, err
+		}
+		tiers = append(tiers, ticketTierModelToDomain(tierModel))
+	}
+	return tiers, rows.Err()
+}
+
+func (tr ticketTierRepository) Save(tier domain.TicketTier) (domain.TicketTier, error) {
+	tierModel := ticketTierDomainToModel(tier)
+	err := withTransaction(tr.db, func(tx *sql.Tx) error {
+		err := saveTicketTier(tx, &tierModel)
+		if err != nil {
+			return err
+		}
+		return syncPartyPrice(tx, tierModel.PartyId)
+	})
+	if err != nil {
+		return domain.TicketTier{}, err
+	}
+	return ticketTierModelToDomain(tierModel), nil
+}
+
+func (tr ticketTierRepository) Update(tier domain.TicketTier) (domain.TicketTier, error) {
+	tierModel := ticketTierDomainToModel(tier)
+	sqlCommand := `UPDATE ticket_tiers SET name = $1, price = $2, capacity = $3
+	WHERE id = $4 AND party_id = $5 AND sold <= $3 RETURNING ` + ticketTierColumns
+	err := withTransaction(tr.db, func(tx *sql.Tx) error {
+		var err error
+		tierModel, err = scanTicketTier(tx.QueryRow(
+			sqlCommand,
+			tierModel.Name,
+			tierModel.Price,
+			tierModel.Capacity,
+			tierModel.Id,
+			tierModel.PartyId,
+		))
+		if err != nil {
+			return err
+		}
+		return syncPartyPrice(tx, tierModel.PartyId)
+	})
+	if err != nil {
+		return domain.TicketTier{}, err
+	}
+	return ticketTierModelToDomain(tierModel), nil
+}
+
+func (tr ticketTierRepository) Delete(partyId, id uint64) error {
+	return withTransaction(tr.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM ticket_tiers WHERE id = $1 AND party_id = $2 AND sold = 0`, id, partyId)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+		return syncPartyPrice(tx, partyId)
+	})
+}
+
+func saveTicketTier(tx *sql.Tx, tierModel *ticketTier) error {
+	sqlCommand := `INSERT INTO ticket_tiers (party_id, name, price, capacity)
+	VALUES ($1, $2, $3, $4) ON CONFLICT (party_id, name) DO NOTHING RETURNING id, sold, created_date`
+	err := tx.QueryRow(
+		sqlCommand,
+		tierModel.PartyId,
+		tierModel.Name,
+		tierModel.Price,
+		tierModel.Capacity,
+	).Scan(
+		&tierModel.Id,
+		&tierModel.Sold,
+		&tierModel.CreatedDate,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTierExists
+	}
+	return err
+}
+
+func syncPartyPrice(tx *sql.Tx, partyId uint64) error {
+	sqlCommand := `UPDATE parties SET price = t.price
+	FROM (SELECT MIN(price) AS price FROM ticket_tiers WHERE party_id = $1) t
+	WHERE parties.id = $1 AND t.price IS NOT NULL`
+	_, err := tx.Exec(sqlCommand, partyId)
+	return err
+}
+
+func takeTicket(tx *sql.Tx, tierId uint64) error {
+	result, err := tx.Exec(`UPDATE ticket_tiers SET sold = sold + 1 WHERE id = $1 AND sold < capacity`, tierId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTierSoldOut
+	}
+	return nil
+}
+
+func releaseTicket(tx *sql.Tx, tierId uint64) error {
+	_, err := tx.Exec(`UPDATE ticket_tiers SET sold = sold - 1 WHERE id = $1 AND sold > 0`, tierId)
+	return err
+}
+
+func scanTicketTier(row interface{ Scan(dest ...any) error }) (ticketTier, error) {
+	tierModel := ticketTier{}
+	err := row.Scan(
+		&tierModel.Id,
+		&tierModel.PartyId,
+		&tierModel.Name,
+		&tierModel.Price,
+		&tierModel.Capacity,
+		&tierModel.Sold,
+		&tierModel.CreatedDate,
+	)
+	return tierModel, err
+}
+
+func ticketTierDomainToModel(t domain.TicketTier) ticketTier {
+	return ticketTier{
+		Id:          t.Id,
+		PartyId:     t.PartyId,
+		Name:        t.Name,
+		Price:       t.Price,
+		Capacity:    t.Capacity,
+		Sold:        t.Sold,
+		CreatedDate: t.CreatedDate,
+	}
+}
+
+func ticketTierModelToDomain(t ticketTier) domain.TicketTier {
+	return domain.TicketTier{
+		Id:          t.Id,
+		PartyId:     t.PartyId,
+		Name:        t.Name,
+		Price:       t.Price,
+		Capacity:    t.Capacity,
+		Sold:        t.Sold,
+		CreatedDate: t.CreatedDate,
+	}
+}
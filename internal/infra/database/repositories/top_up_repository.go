@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+var ErrPaymentEventProcessed = errors.New("payment event was already processed")
+
+const topUpColumns = `id, user_id, amount, provider, provider_session_id, checkout_url, status, completed_date, created_date`
+
+type topUp struct {
+	Id                uint64       `db:"id, omitempty"`
+	UserId            uint64       `db:"user_id"`
+	Amount            int32        `db:"amount"`
+	Provider          string       `db:"provider"`
+	ProviderSessionId string       `db:"provider_session_id"`
+	CheckoutUrl       string       `db:"checkout_url"`
+	Status            string       `db:"status"`
+	CompletedDate     sql.NullTime `db:"completed_date"`
+	CreatedDate       time.Time    `db:"created_date"`
+}
+
+type TopUpRepository interface {
+	FindById(id uint64) (domain.TopUp, error)
+	Save(topUp domain.TopUp) (domain.TopUp, error)
+	Complete(provider string, event domain.PaymentEvent) (domain.TopUp, error)
+	Fail(provider string, event domain.PaymentEvent) (domain.TopUp, error)
+}
+
+type topUpRepository struct {
+	db *sql.DB
+}
+
+func NewTopUpRepository(db *sql.DB) TopUpRepository {
+	return topUpRepository{db: db}
+}
+
+func (tr topUpRepository) FindById(id uint64) (domain.TopUp, error) {
+	sqlCommand := `SELECT ` + topUpColumns + ` FROM top_ups WHERE id = $1`
+	topUpModel, err := tr.scan(tr.db.QueryRow(sqlCommand, id))
+	if err != nil {
+		return domain.TopUp{}, err
+	}
+	return tr.modelToDomain(topUpModel), nil
+}
+
+func (tr topUpRepository) Save(topUp domain.TopUp) (domain.TopUp, error) {
+	topUpModel := tr.domainToModel(topUp)
+	sqlCommand := `INSERT INTO top_ups (user_id, amount, provider, provider_session_id, checkout_url)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id, status, created_date`
+	err := tr.db.QueryRow(
+		sqlCommand,
+		topUpModel.UserId,
+		topUpModel.Amount,
+		topUpModel.Provider,
+		topUpModel.ProviderSessionId,
+		topUpModel.CheckoutUrl,
+	).Scan(
+		&topUpModel.Id,
+		&topUpModel.Status,
+		&topUpModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.TopUp{}, err
+	}
+	return tr.modelToDomain(topUpModel), nil
+}
+
+func (tr topUpRepository) Complete(provider string, event domain.PaymentEvent) (domain.TopUp, error) {
+	var topUpModel topUp
+	err := withTransaction(tr.db, func(tx *sql.Tx) error {
+		var err error
+		topUpModel, err = tr.finish(tx, provider, event, domain.TopUpCompleted)
+		if err != nil {
+			return err
+		}
+
+		return applyPointTransaction(tx, &pointTransaction{
+			UserId: topUpModel.UserId,
+			Amount: topUpModel.Amount,
+			Type:   domain.PointTransactionTopUp,
+			Reason: fmt.Sprintf("top-up #%d", topUpModel.Id),
+		})
+	})
+	if err != nil {
+		return domain.TopUp{}, err
+	}
+	return tr.modelToDomain(topUpModel), nil
+}
+
+func (tr topUpRepository) Fail(provider string, event domain.PaymentEvent) (domain.TopUp, error) {
+	var topUpModel topUp
+	err := withTransaction(tr.db, func(tx *sql.Tx) error {
+		var err error
+		topUpModel, err = tr.finish(tx, provider, event, domain.TopUpFailed)
+		return err
+	})
+	if err != nil {
+		return domain.TopUp{}, err
+	}
+	return tr.modelToDomain(topUpModel), nil
+}
+
+func (tr topUpRepository) finish(tx *sql.Tx, provider string, event domain.PaymentEvent, status string) (topUp, error) {
+	result, err := tx.Exec(
+		`INSERT INTO payment_events (provider, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		provider,
+		event.Id,
+	)
+	if err != nil {
+		return topUp{}, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return topUp{}, err
+	}
+	if affected == 0 {
+		return topUp{}, ErrPaymentEventProcessed
+	}
+
+	sqlCommand := `UPDATE top_ups SET status = $1, completed_date = NOW()
+	WHERE provider = $2 AND provider_session_id = $3 AND status = $4 RETURNING ` + topUpColumns
+	topUpModel, err := tr.scan(tx.QueryRow(sqlCommand, status, provider, event.SessionId, domain.TopUpPending))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return topUp{}, ErrPaymentEventProcessed
+		}
+		return topUp{}, err
+	}
+	return topUpModel, nil
+}
+
+func (tr topUpRepository) scan(row interface{ Scan(dest ...any) error }) (topUp, error) {
+	topUpModel := topUp{}
+	err := row.Scan(
+		&topUpModel.Id,
+		&topUpModel.UserId,
+		&topUpModel.Amount,
+		&topUpModel.Provider,
+		&topUpModel.ProviderSessionId,
+		&topUpModel.CheckoutUrl,
+		&topUpModel.Status,
+		&topUpModel.CompletedDate,
+		&topUpModel.CreatedDate,
+	)
+	return topUpModel, err
+}
+
+func (tr topUpRepository) domainToModel(t domain.TopUp) topUp {
+	model := topUp{
+		Id:                t.Id,
+		UserId:            t.UserId,
+		Amount:            t.Amount,
+		Provider:          t.Provider,
+		ProviderSessionId: t.ProviderSessionId,
+		CheckoutUrl:       t.CheckoutUrl,
+		Status:            t.Status,
+		CreatedDate:       t.CreatedDate,
+	}
+	if t.CompletedDate != nil {
+		model.CompletedDate = sql.NullTime{Time: *t.CompletedDate, Valid: true}
+	}
+	return model
+}
+
+func (tr topUpRepository) modelToDomain(t topUp) domain.TopUp {
+	domainTopUp := domain.TopUp{
+		Id:                t.Id,
+		UserId:            t.UserId,
+		Amount:            t.Amount,
+		Provider:          t.Provider,
+		ProviderSessionId: t.ProviderSessionId,
+		CheckoutUrl:       t.CheckoutUrl,
+		Status:            t.Status,
+		CreatedDate:       t.CreatedDate,
+	}
+	if t.CompletedDate.Valid {
+		domainTopUp.CompletedDate = &t.CompletedDate.Time
+	}
+	return domainTopUp
+}
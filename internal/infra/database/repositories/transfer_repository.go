@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+var ErrDailyTransferLimitExceeded = errors.New("daily transfer limit exceeded")
+
+type transfer struct {
+	Id          uint64        `db:"id, omitempty"`
+	SenderId    uint64        `db:"sender_id"`
+	RecipientId uint64        `db:"recipient_id"`
+	PartyId     sql.NullInt64 `db:"party_id"`
+	Amount      int32         `db:"amount"`
+	Note        string        `db:"note"`
+	Balance     int32         `db:"-"`
+	CreatedDate time.Time     `db:"created_date"`
+}
+
+type TransferRepository interface {
+	Save(transfer domain.Transfer, dailyLimit int32) (domain.Transfer, error)
+}
+
+type transferRepository struct {
+	db *sql.DB
+}
+
+func NewTransferRepository(db *sql.DB) TransferRepository {
+	return transferRepository{db: db}
+}
+
+// Both users are locked in id order so opposite transfers can't deadlock.
+func (tr transferRepository) Save(transfer domain.Transfer, dailyLimit int32) (domain.Transfer, error) {
+	transferModel := tr.domainToModel(transfer)
+	err := withTransaction(tr.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			`SELECT id FROM users WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`,
+			transferModel.SenderId,
+			transferModel.RecipientId,
+		)
+		if err != nil {
+			return err
+		}
+
+		var sent int64
+		sqlCommand := `SELECT COALESCE(SUM(amount), 0) FROM transfers
+		WHERE sender_id = $1 AND created_date > NOW() - INTERVAL '1 day'`
+		err = tx.QueryRow(sqlCommand, transferModel.SenderId).Scan(&sent)
+		if err != nil {
+			return err
+		}
+		if sent+int64(transferModel.Amount) > int64(dailyLimit) {
+			return ErrDailyTransferLimitExceeded
+		}
+
+		sqlCommand = `INSERT INTO transfers (sender_id, recipient_id, party_id, amount, note)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_date`
+		err = tx.QueryRow(
+			sqlCommand,
+			transferModel.SenderId,
+			transferModel.RecipientId,
+			transferModel.PartyId,
+			transferModel.Amount,
+			transferModel.Note,
+		).Scan(
+			&transferModel.Id,
+			&transferModel.CreatedDate,
+		)
+		if err != nil {
+			return err
+		}
+
+		transactionType := domain.PointTransactionTransfer
+		if transferModel.PartyId.Valid {
+			transactionType = domain.PointTransactionTip
+		}
+		reason := fmt.Sprintf("%s #%d", transactionType, transferModel.Id)
+
+		debit := pointTransaction{
+			UserId: transferModel.SenderId,
+			Amount: -transferModel.Amount,
+			Type:   transactionType,
+			Reason: reason,
+		}
+		err = applyPointTransaction(tx, &debit)
+		if err != nil {
+			return err
+		}
+		transferModel.Balance = debit.Balance
+
+		err = applyPointTransaction(tx, &pointTransaction{
+			UserId: transferModel.RecipientId,
+			Amount: transferModel.Amount,
+			Type:   transactionType,
+			Reason: reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if transferModel.PartyId.Valid {
+			_, err = tx.Exec(
+				`UPDATE parties SET tips_total = tips_total + $1 WHERE id = $2`,
+				transferModel.Amount,
+				transferModel.PartyId,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return saveEvent(tx, domain.NewTransferEvent(domain.TransferReceivedEvent, tr.modelToDomain(transferModel)))
+	})
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	return tr.modelToDomain(transferModel), nil
+}
+
+func (tr transferRepository) domainToModel(t domain.Transfer) transfer {
+	model := transfer{
+		Id:          t.Id,
+		SenderId:    t.SenderId,
+		RecipientId: t.RecipientId,
+		Amount:      t.Amount,
+		Note:        t.Note,
+		Balance:     t.Balance,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.PartyId != nil {
+		model.PartyId = sql.NullInt64{Int64: int64(*t.PartyId), Valid: true}
+	}
+	return model
+}
+
+func (tr transferRepository) modelToDomain(t transfer) domain.Transfer {
+	domainTransfer := domain.Transfer{
+		Id:          t.Id,
+		SenderId:    t.SenderId,
+		RecipientId: t.RecipientId,
+		Amount:      t.Amount,
+		Note:        t.Note,
+		Balance:     t.Balance,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.PartyId.Valid {
+		partyId := uint64(t.PartyId.Int64)
+		domainTransfer.PartyId = &partyId
+	}
+	return domainTransfer
+}
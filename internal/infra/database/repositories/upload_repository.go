@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const uploadColumns = `id, user_id, url, medium_url, thumbnail_url, content_type, size, created_date`
+
+type upload struct {
+	Id           uuid.UUID `db:"id"`
+	UserId       uint64    `db:"user_id"`
+	Url          string    `db:"url"`
+	MediumUrl    string    `db:"medium_url"`
+	ThumbnailUrl string    `db:"thumbnail_url"`
+	ContentType  string    `db:"content_type"`
+	Size         int64     `db:"size"`
+	CreatedDate  time.Time `db:"created_date"`
+}
+
+type UploadRepository interface {
+	Save(upload domain.Upload) (domain.Upload, error)
+	Take(id uuid.UUID, userId uint64) (domain.Upload, error)
+}
+
+type uploadRepository struct {
+	db *sql.DB
+}
+
+func NewUploadRepository(db *sql.DB) UploadRepository {
+	return uploadRepository{db: db}
+}
+
+func (ur uploadRepository) Save(upload domain.Upload) (domain.Upload, error) {
+	uploadModel := ur.domainToModel(upload)
+	sqlCommand := `INSERT INTO uploads (id, user_id, url, medium_url, thumbnail_url, content_type, size) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING created_date`
+	err := ur.db.QueryRow(
+		sqlCommand,
+		uploadModel.Id,
+		uploadModel.UserId,
+		uploadModel.Url,
+		uploadModel.MediumUrl,
+		uploadModel.ThumbnailUrl,
+		uploadModel.ContentType,
+		uploadModel.Size,
+	).Scan(&uploadModel.CreatedDate)
+	if err != nil {
+		return domain.Upload{}, err
+	}
+	return ur.modelToDomain(uploadModel), nil
+}
+
+func (ur uploadRepository) Take(id uuid.UUID, userId uint64) (domain.Upload, error) {
+	sqlCommand := `DELETE FROM uploads WHERE id = $1 AND user_id = $2 RETURNING ` + uploadColumns
+	uploadModel, err := ur.scan(ur.db.QueryRow(sqlCommand, id, userId))
+	if err != nil {
+		return domain.Upload{}, err
+	}
+	return ur.modelToDomain(uploadModel), nil
+}
+
+func (ur uploadRepository) scan(row interface{ Scan(dest ...any) error }) (upload, error) {
+	uploadModel := upload{}
+	err := row.Scan(
+		&uploadModel.Id,
+		&uploadModel.UserId,
+		&uploadModel.Url,
+		&uploadModel.MediumUrl,
+		&uploadModel.ThumbnailUrl,
+		&uploadModel.ContentType,
+		&uploadModel.Size,
+		&uploadModel.CreatedDate,
+	)
+	return uploadModel, err
+}
+
+func (ur uploadRepository) domainToModel(u domain.Upload) upload {
+	return upload{
+		Id:           u.Id,
+		UserId:       u.UserId,
+		Url:          u.Url,
+		MediumUrl:    u.MediumUrl,
+		ThumbnailUrl: u.ThumbnailUrl,
+		ContentType:  u.ContentType,
+		Size:         u.Size,
+		CreatedDate:  u.CreatedDate,
+	}
+}
+
+func (ur uploadRepository) modelToDomain(u upload) domain.Upload {
+	return domain.Upload{
+		Id:           u.Id,
+		UserId:       u.UserId,
+		Url:          u.Url,
+		MediumUrl:    u.MediumUrl,
+		ThumbnailUrl: u.ThumbnailUrl,
+		ContentType:  u.ContentType,
+		Size:         u.Size,
+		CreatedDate:  u.CreatedDate,
+	}
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type userIdentity struct {
+	Id          uint64    `db:"id, omitempty"`
+	UserId      uint64    `db:"user_id"`
+	Provider    string    `db:"provider"`
+	Subject     string    `db:"subject"`
+	Email       string    `db:"email"`
+	CreatedDate time.Time `db:"created_date"`
+}
+
+type UserIdentityRepository interface {
+	Save(identity domain.UserIdentity) (domain.UserIdentity, error)
+	FindByProviderAndSubject(provider, subject string) (domain.UserIdentity, error)
+}
+
+type userIdentityRepository struct {
+	db *sql.DB
+}
+
+func NewUserIdentityRepository(db *sql.DB) UserIdentityRepository {
+	return userIdentityRepository{db: db}
+}
+
+func (ui userIdentityRepository) Save(identity domain.UserIdentity) (domain.UserIdentity, error) {
+	identityModel := ui.domainToModel(identity)
+	sqlCommand := `INSERT INTO user_identities (user_id, provider, subject, email)
+	VALUES ($1, $2, $3, $4) RETURNING id, created_date`
+	err := ui.db.QueryRow(
+		sqlCommand,
+		identityModel.UserId,
+		identityModel.Provider,
+		identityModel.Subject,
+		identityModel.Email,
+	).Scan(
+		&identityModel.Id,
+		&identityModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.UserIdentity{}, err
+	}
+	return ui.modelToDomain(identityModel), nil
+}
+
+func (ui userIdentityRepository) FindByProviderAndSubject(provider, subject string) (domain.UserIdentity, error) {
+	identityModel := userIdentity{}
+	sqlCommand := `SELECT id, user_id, provider, subject, email, created_date
+	FROM user_identities WHERE provider = $1 AND subject = $2`
+	err := ui.db.QueryRow(sqlCommand, provider, subject).Scan(
+		&identityModel.Id,
+		&identityModel.UserId,
+		&identityModel.Provider,
+		&identityModel.Subject,
+		&identityModel.Email,
+		&identityModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.UserIdentity{}, err
+	}
+	return ui.modelToDomain(identityModel), nil
+}
+
+func (ui userIdentityRepository) domainToModel(i domain.UserIdentity) userIdentity {
+	return userIdentity{
+		Id:          i.Id,
+		UserId:      i.UserId,
+		Provider:    i.Provider,
+		Subject:     i.Subject,
+		Email:       i.Email,
+		CreatedDate: i.CreatedDate,
+	}
+}
+
+func (ui userIdentityRepository) modelToDomain(i userIdentity) domain.UserIdentity {
+	return domain.UserIdentity{
+		Id:          i.Id,
+		UserId:      i.UserId,
+		Provider:    i.Provider,
+		Subject:     i.Subject,
+		Email:       i.Email,
+		CreatedDate: i.CreatedDate,
+	}
+}
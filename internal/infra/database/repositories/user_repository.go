@@ -5,12 +5,20 @@ import (
 	"go-rest-api/internal/domain"
 )
 
+const userColumns = `id, name, email, password, points, verified, totp_secret, totp_enabled, role, banned_date, ban_reason`
+
 type user struct {
-	Id       uint64 `db:"id, omitempty"`
-	Name     string `db:"name"`
-	Email    string `db:"email"`
-	Password string `db:"password"`
-	Points   int32  `db:"points"`
+	Id          uint64       `db:"id, omitempty"`
+	Name        string       `db:"name"`
+	Email       string       `db:"email"`
+	Password    string       `db:"password"`
+	Points      int32        `db:"points"`
+	Verified    bool         `db:"verified"`
+	TotpSecret  string       `db:"totp_secret"`
+	TotpEnabled bool         `db:"totp_enabled"`
+	Role        string       `db:"role"`
+	BannedDate  sql.NullTime `db:"banned_date"`
+	BanReason   string       `db:"ban_reason"`
 }
 
 type UserRepository interface {
@@ -18,6 +26,15 @@ type UserRepository interface {
 	FindByEmail(email string) (domain.User, error)
 	FindById(id uint64) (domain.User, error)
 	Save(user domain.User) (domain.User, error)
+	UpdatePassword(id uint64, password string) error
+	MarkVerified(id uint64) error
+	UpdateEmail(id uint64, email string) error
+	UpdateTotp(id uint64, secret string, enabled bool) error
+	UseTotpStep(id uint64, step int64) error
+	Search(query string, page, limit int32) (domain.Users, error)
+	UpdateRole(id uint64, role domain.Role) error
+	Ban(id uint64, reason string) error
+	Unban(id uint64) error
 	Delete(id uint64) error
 }
 type userRepository struct {
@@ -43,19 +60,8 @@ func (ur userRepository) UpdateUserBalance(user domain.User, amount int32) (doma
 }
 
 func (ur userRepository) FindByEmail(email string) (domain.User, error) {
-	userModel := user{}
-	sqlCommand := `SELECT * FROM users WHERE email=$1`
-
-	err := ur.db.QueryRow(
-		sqlCommand,
-		email,
-	).Scan(
-		&userModel.Id,
-		&userModel.Name,
-		&userModel.Email,
-		&userModel.Password,
-		&userModel.Points,
-	)
+	sqlCommand := `SELECT ` + userColumns + ` FROM users WHERE email=$1`
+	userModel, err := ur.scan(ur.db.QueryRow(sqlCommand, email))
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -64,18 +70,8 @@ func (ur userRepository) FindByEmail(email string) (domain.User, error) {
 }
 
 func (ur userRepository) FindById(id uint64) (domain.User, error) {
-	userModel := user{}
-	sqlCommand := `SELECT * FROM users WHERE id=$1`
-	err := ur.db.QueryRow(
-		sqlCommand,
-		id,
-	).Scan(
-		&userModel.Id,
-		&userModel.Name,
-		&userModel.Email,
-		&userModel.Password,
-		&userModel.Points,
-	)
+	sqlCommand := `SELECT ` + userColumns + ` FROM users WHERE id=$1`
+	userModel, err := ur.scan(ur.db.QueryRow(sqlCommand, id))
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -85,7 +81,7 @@ func (ur userRepository) FindById(id uint64) (domain.User, error) {
 
 func (ur userRepository) Save(user domain.User) (domain.User, error) {
 	userModel := ur.domainToModel(user)
-	sqlCommand := `INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING id, points`
+	sqlCommand := `INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING id, points, verified, role`
 
 	err := ur.db.QueryRow(
 		sqlCommand,
@@ -95,6 +91,8 @@ func (ur userRepository) Save(user domain.User) (domain.User, error) {
 	).Scan(
 		&userModel.Id,
 		&userModel.Points,
+		&userModel.Verified,
+		&userModel.Role,
 	)
 	if err != nil {
 		return domain.User{}, err
@@ -103,6 +101,134 @@ func (ur userRepository) Save(user domain.User) (domain.User, error) {
 	return ur.modelToDomain(userModel), nil
 }
 
+func (ur userRepository) UpdatePassword(id uint64, password string) error {
+	sqlCommand := `UPDATE users SET password = $1 WHERE id = $2`
+	_, err := ur.db.Exec(sqlCommand, password, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur userRepository) MarkVerified(id uint64) error {
+	sqlCommand := `UPDATE users SET verified = true WHERE id = $1`
+	_, err := ur.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur userRepository) UpdateEmail(id uint64, email string) error {
+	sqlCommand := `UPDATE users SET email = $1, verified = true WHERE id = $2`
+	_, err := ur.db.Exec(sqlCommand, email, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur userRepository) UpdateTotp(id uint64, secret string, enabled bool) error {
+	sqlCommand := `UPDATE users SET totp_secret = $1, totp_enabled = $2 WHERE id = $3`
+	_, err := ur.db.Exec(sqlCommand, secret, enabled, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UseTotpStep returns sql.ErrNoRows when a code of this or a later step was already used.
+func (ur userRepository) UseTotpStep(id uint64, step int64) error {
+	sqlCommand := `UPDATE users SET totp_last_step = $1 WHERE id = $2 AND totp_last_step < $1`
+	result, err := ur.db.Exec(sqlCommand, step, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (ur userRepository) Search(query string, page, limit int32) (domain.Users, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+	pattern := "%" + query + "%"
+
+	var users []domain.User
+
+	sqlCommand := `SELECT ` + userColumns + ` FROM users
+	WHERE name ILIKE $1 OR email ILIKE $1 ORDER BY id LIMIT $2 OFFSET $3`
+	rows, err := ur.db.Query(sqlCommand, pattern, limit, offset)
+	if err != nil {
+		return domain.Users{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		userModel, err := ur.scan(rows)
+		if err != nil {
+			return domain.Users{}, err
+		}
+		users = append(users, ur.modelToDomain(userModel))
+	}
+
+	var total uint64
+	totalSqlCommand := `SELECT COUNT(*) FROM users WHERE name ILIKE $1 OR email ILIKE $1`
+	err = ur.db.QueryRow(totalSqlCommand, pattern).Scan(&total)
+	if err != nil {
+		return domain.Users{}, err
+	}
+	var pages int32
+	if total > 0 {
+		pages = (int32(total) + limit - 1) / limit
+	}
+
+	return domain.Users{
+		Users:       users,
+		Total:       total,
+		CurrentPage: page,
+		LastPage:    pages,
+	}, nil
+}
+
+func (ur userRepository) UpdateRole(id uint64, role domain.Role) error {
+	sqlCommand := `UPDATE users SET role = $1 WHERE id = $2`
+	_, err := ur.db.Exec(sqlCommand, string(role), id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur userRepository) Ban(id uint64, reason string) error {
+	sqlCommand := `UPDATE users SET banned_date = NOW(), ban_reason = $1 WHERE id = $2`
+	_, err := ur.db.Exec(sqlCommand, reason, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur userRepository) Unban(id uint64) error {
+	sqlCommand := `UPDATE users SET banned_date = NULL, ban_reason = '' WHERE id = $1`
+	_, err := ur.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (ur userRepository) Delete(id uint64) error {
 	sqlCommand := `DELETE FROM users WHERE id=$1`
 	_, err := ur.db.Exec(sqlCommand, id)
@@ -112,22 +238,58 @@ func (ur userRepository) Delete(id uint64) error {
 	return nil
 }
 
+func (ur userRepository) scan(row interface{ Scan(dest ...any) error }) (user, error) {
+	userModel := user{}
+	err := row.Scan(
+		&userModel.Id,
+		&userModel.Name,
+		&userModel.Email,
+		&userModel.Password,
+		&userModel.Points,
+		&userModel.Verified,
+		&userModel.TotpSecret,
+		&userModel.TotpEnabled,
+		&userModel.Role,
+		&userModel.BannedDate,
+		&userModel.BanReason,
+	)
+	return userModel, err
+}
+
 func (ur userRepository) modelToDomain(u user) domain.User {
-	return domain.User{
-		Id:       u.Id,
-		Name:     u.Name,
-		Email:    u.Email,
-		Password: u.Password,
-		Points:   u.Points,
+	domainUser := domain.User{
+		Id:          u.Id,
+		Name:        u.Name,
+		Email:       u.Email,
+		Password:    u.Password,
+		Points:      u.Points,
+		Verified:    u.Verified,
+		TotpSecret:  u.TotpSecret,
+		TotpEnabled: u.TotpEnabled,
+		Role:        domain.Role(u.Role),
+		BanReason:   u.BanReason,
+	}
+	if u.BannedDate.Valid {
+		domainUser.BannedDate = &u.BannedDate.Time
 	}
+	return domainUser
 }
 
 func (ur userRepository) domainToModel(u domain.User) user {
-	return user{
-		Id:       u.Id,
-		Name:     u.Name,
-		Email:    u.Email,
-		Password: u.Password,
-		Points:   u.Points,
+	model := user{
+		Id:          u.Id,
+		Name:        u.Name,
+		Email:       u.Email,
+		Password:    u.Password,
+		Points:      u.Points,
+		Verified:    u.Verified,
+		TotpSecret:  u.TotpSecret,
+		TotpEnabled: u.TotpEnabled,
+		Role:        string(u.Role),
+		BanReason:   u.BanReason,
+	}
+	if u.BannedDate != nil {
+		model.BannedDate = sql.NullTime{Time: *u.BannedDate, Valid: true}
 	}
+	return model
 }
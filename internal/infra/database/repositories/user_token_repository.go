@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type userToken struct {
+	Id          uint64       `db:"id, omitempty"`
+	UserId      uint64       `db:"user_id"`
+	Purpose     string       `db:"purpose"`
+	TokenHash   string       `db:"token_hash"`
+	Payload     string       `db:"payload"`
+	ExpiresDate time.Time    `db:"expires_date"`
+	UsedDate    sql.NullTime `db:"used_date"`
+	CreatedDate time.Time    `db:"created_date"`
+}
+
+type UserTokenRepository interface {
+	Save(token domain.UserToken) (domain.UserToken, error)
+	FindByHash(purpose, tokenHash string) (domain.UserToken, error)
+	MarkUsed(id uint64) error
+	DeleteByUserId(userId uint64, purpose string) error
+}
+
+type userTokenRepository struct {
+	db *sql.DB
+}
+
+func NewUserTokenRepository(db *sql.DB) UserTokenRepository {
+	return userTokenRepository{db: db}
+}
+
+func (ut userTokenRepository) Save(token domain.UserToken) (domain.UserToken, error) {
+	tokenModel := ut.domainToModel(token)
+	sqlCommand := `INSERT INTO user_tokens (user_id, purpose, token_hash, payload, expires_date) 
+	VALUES ($1, $2, $3, $4, $5) RETURNING id, created_date`
+	err := ut.db.QueryRow(
+		sqlCommand,
+		tokenModel.UserId,
+		tokenModel.Purpose,
+		tokenModel.TokenHash,
+		tokenModel.Payload,
+		tokenModel.ExpiresDate,
+	).Scan(
+		&tokenModel.Id,
+		&tokenModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.UserToken{}, err
+	}
+	return ut.modelToDomain(tokenModel), nil
+}
+
+func (ut userTokenRepository) FindByHash(purpose, tokenHash string) (domain.UserToken, error) {
+	tokenModel := userToken{}
+	sqlCommand := `SELECT id, user_id, purpose, token_hash, payload, expires_date, used_date, created_date 
+	FROM user_tokens WHERE purpose = $1 AND token_hash = $2`
+	err := ut.db.QueryRow(sqlCommand, purpose, tokenHash).Scan(
+		&tokenModel.Id,
+		&tokenModel.UserId,
+		&tokenModel.Purpose,
+		&tokenModel.TokenHash,
+		&tokenModel.Payload,
+		&tokenModel.ExpiresDate,
+		&tokenModel.UsedDate,
+		&tokenModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.UserToken{}, err
+	}
+	return ut.modelToDomain(tokenModel), nil
+}
+
+func (ut userTokenRepository) MarkUsed(id uint64) error {
+	sqlCommand := `UPDATE user_tokens SET used_date = NOW() WHERE id = $1 AND used_date IS NULL`
+	result, err := ut.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("token already used")
+	}
+	return nil
+}
+
+func (ut userTokenRepository) DeleteByUserId(userId uint64, purpose string) error {
+	sqlCommand := `DELETE FROM user_tokens WHERE user_id = $1 AND purpose = $2`
+	_, err := ut.db.Exec(sqlCommand, userId, purpose)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ut userTokenRepository) domainToModel(t domain.UserToken) userToken {
+	model := userToken{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		Purpose:     t.Purpose,
+		TokenHash:   t.TokenHash,
+		Payload:     t.Payload,
+		ExpiresDate: t.ExpiresDate,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.UsedDate != nil {
+		model.UsedDate = sql.NullTime{Time: *t.UsedDate, Valid: true}
+	}
+	return model
+}
+
+func (ut userTokenRepository) modelToDomain(t userToken) domain.UserToken {
+	token := domain.UserToken{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		Purpose:     t.Purpose,
+		TokenHash:   t.TokenHash,
+		Payload:     t.Payload,
+		ExpiresDate: t.ExpiresDate,
+		CreatedDate: t.CreatedDate,
+	}
+	if t.UsedDate.Valid {
+		token.UsedDate = &t.UsedDate.Time
+	}
+	return token
+}
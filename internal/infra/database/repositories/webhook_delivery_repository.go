@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type webhookDelivery struct {
+	Id          uint64    `db:"id, omitempty"`
+	WebhookId   uint64    `db:"webhook_id"`
+	Event       string    `db:"event"`
+	Payload     string    `db:"payload"`
+	Attempt     int32     `db:"attempt"`
+	StatusCode  int32     `db:"status_code"`
+	Success     bool      `db:"success"`
+	Error       string    `db:"error"`
+	CreatedDate time.Time `db:"created_date"`
+}
+
+type WebhookDeliveryRepository interface {
+	Save(delivery domain.WebhookDelivery) (domain.WebhookDelivery, error)
+	FindByWebhookId(webhookId uint64, page, limit int32) (domain.WebhookDeliveries, error)
+}
+
+type webhookDeliveryRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookDeliveryRepository(db *sql.DB) WebhookDeliveryRepository {
+	return webhookDeliveryRepository{db: db}
+}
+
+func (wr webhookDeliveryRepository) Save(delivery domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	deliveryModel := wr.domainToModel(delivery)
+	sqlCommand := `INSERT INTO webhook_deliveries (
+                   webhook_id,
+                   event,
+                   payload,
+                   attempt,
+                   status_code,
+                   success,
+                   error
+			   ) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_date`
+	err := wr.db.QueryRow(
+		sqlCommand,
+		deliveryModel.WebhookId,
+		deliveryModel.Event,
+		deliveryModel.Payload,
+		deliveryModel.Attempt,
+		deliveryModel.StatusCode,
+		deliveryModel.Success,
+		deliveryModel.Error,
+	).Scan(
+		&deliveryModel.Id,
+		&deliveryModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return wr.modelToDomain(deliveryModel), nil
+}
+
+func (wr webhookDeliveryRepository) FindByWebhookId(webhookId uint64, page, limit int32) (domain.WebhookDeliveries, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+
+	sqlCommand := `SELECT id, webhook_id, event, payload, attempt, status_code, success, error, created_date 
+	FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`
+	rows, err := wr.db.Query(sqlCommand, webhookId, limit, offset)
+	if err != nil {
+		return domain.WebhookDeliveries{}, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		deliveryModel := webhookDelivery{}
+		err := rows.Scan(
+			&deliveryModel.Id,
+			&deliveryModel.WebhookId,
+			&deliveryModel.Event,
+			&deliveryModel.Payload,
+			&deliveryModel.Attempt,
+			&deliveryModel.StatusCode,
+			&deliveryModel.Success,
+			&deliveryModel.Error,
+			&deliveryModel.CreatedDate,
+		)
+		if err != nil {
+			return domain.WebhookDeliveries{}, err
+		}
+		deliveries = append(deliveries, wr.modelToDomain(deliveryModel))
+	}
+
+	var total uint64
+	totalSqlCommand := `SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1`
+	err = wr.db.QueryRow(totalSqlCommand, webhookId).Scan(&total)
+	if err != nil {
+		return domain.WebhookDeliveries{}, err
+	}
+	var pages int32
+	if total > 0 {
+		pages = (int32(total) + limit - 1) / limit
+	}
+
+	return domain.WebhookDeliveries{
+		Deliveries:  deliveries,
+		Total:       total,
+		CurrentPage: page,
+		LastPage:    pages,
+	}, nil
+}
+
+func (wr webhookDeliveryRepository) domainToModel(d domain.WebhookDelivery) webhookDelivery {
+	return webhookDelivery{
+		Id:          d.Id,
+		WebhookId:   d.WebhookId,
+		Event:       d.Event,
+		Payload:     d.Payload,
+		Attempt:     d.Attempt,
+		StatusCode:  d.StatusCode,
+		Success:     d.Success,
+		Error:       d.Error,
+		CreatedDate: d.CreatedDate,
+	}
+}
+
+func (wr webhookDeliveryRepository) modelToDomain(d webhookDelivery) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		Id:          d.Id,
+		WebhookId:   d.WebhookId,
+		Event:       d.Event,
+		Payload:     d.Payload,
+		Attempt:     d.Attempt,
+		StatusCode:  d.StatusCode,
+		Success:     d.Success,
+		Error:       d.Error,
+		CreatedDate: d.CreatedDate,
+	}
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type webhookJob struct {
+	Id              uint64    `db:"id, omitempty"`
+	WebhookId       uint64    `db:"webhook_id"`
+	EventId         uint64    `db:"event_id"`
+	Event           string    `db:"event"`
+	Payload         string    `db:"payload"`
+	Attempt         int32     `db:"attempt"`
+	NextAttemptDate time.Time `db:"next_attempt_date"`
+	CreatedDate     time.Time `db:"created_date"`
+}
+
+type WebhookJobRepository interface {
+	Enqueue(jobs []domain.WebhookJob) error
+	ClaimDue(limit int32, lease time.Duration) ([]domain.WebhookJob, error)
+	Reschedule(id uint64, attempt int32, delay time.Duration) error
+	Delete(id uint64) error
+}
+
+type webhookJobRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookJobRepository(db *sql.DB) WebhookJobRepository {
+	return webhookJobRepository{db: db}
+}
+
+// Enqueue ignores jobs that already exist, so an event dispatched again after
+// a failure isn't delivered twice.
+func (wr webhookJobRepository) Enqueue(jobs []domain.WebhookJob) error {
+	sqlCommand := `INSERT INTO webhook_jobs (webhook_id, event_id, event, payload) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (webhook_id, event_id) DO NOTHING`
+	return withTransaction(wr.db, func(tx *sql.Tx) error {
+		for _, job := range jobs {
+			jobModel := wr.domainToModel(job)
+			_, err := tx.Exec(sqlCommand, jobModel.WebhookId, jobModel.EventId, jobModel.Event, jobModel.Payload)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimDue pushes the next attempt of the returned jobs back by lease, so
+// other instances skip them while they are being delivered.
+func (wr webhookJobRepository) ClaimDue(limit int32, lease time.Duration) ([]domain.WebhookJob, error) {
+	sqlCommand := `UPDATE webhook_jobs SET next_attempt_date = NOW() + make_interval(secs => $2)
+	WHERE id IN (
+		SELECT id FROM webhook_jobs WHERE next_attempt_date <= NOW() ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, webhook_id, event_id, event, payload, attempt, next_attempt_date, created_date`
+	rows, err := wr.db.Query(sqlCommand, limit, lease.Seconds())
+	if err != nil {
+		return []domain.WebhookJob{}, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.WebhookJob{}
+	for rows.Next() {
+		jobModel := webhookJob{}
+		err = rows.Scan(
+			&jobModel.Id,
+			&jobModel.WebhookId,
+			&jobModel.EventId,
+			&jobModel.Event,
+			&jobModel.Payload,
+			&jobModel.Attempt,
+			&jobModel.NextAttemptDate,
+			&jobModel.CreatedDate,
+		)
+		if err != nil {
+			return []domain.WebhookJob{}, err
+		}
+		jobs = append(jobs, wr.modelToDomain(jobModel))
+	}
+	if err = rows.Err(); err != nil {
+		return []domain.WebhookJob{}, err
+	}
+	return jobs, nil
+}
+
+func (wr webhookJobRepository) Reschedule(id uint64, attempt int32, delay time.Duration) error {
+	sqlCommand := `UPDATE webhook_jobs SET attempt = $1, next_attempt_date = NOW() + make_interval(secs => $2) WHERE id = $3`
+	_, err := wr.db.Exec(sqlCommand, attempt, delay.Seconds(), id)
+	return err
+}
+
+func (wr webhookJobRepository) Delete(id uint64) error {
+	sqlCommand := `DELETE FROM webhook_jobs WHERE id = $1`
+	_, err := wr.db.Exec(sqlCommand, id)
+	return err
+}
+
+func (wr webhookJobRepository) domainToModel(j domain.WebhookJob) webhookJob {
+	return webhookJob{
+		Id:              j.Id,
+		WebhookId:       j.WebhookId,
+		EventId:         j.EventId,
+		Event:           j.Event,
+		Payload:         j.Payload,
+		Attempt:         j.Attempt,
+		NextAttemptDate: j.NextAttemptDate,
+		CreatedDate:     j.CreatedDate,
+	}
+}
+
+func (wr webhookJobRepository) modelToDomain(j webhookJob) domain.WebhookJob {
+	return domain.WebhookJob{
+		Id:              j.Id,
+		WebhookId:       j.WebhookId,
+		EventId:         j.EventId,
+		Event:           j.Event,
+		Payload:         j.Payload,
+		Attempt:         j.Attempt,
+		NextAttemptDate: j.NextAttemptDate,
+		CreatedDate:     j.CreatedDate,
+	}
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-rest-api/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type webhook struct {
+	Id          uint64    `db:"id, omitempty"`
+	UserId      uint64    `db:"user_id"`
+	Url         string    `db:"url"`
+	Secret      string    `db:"secret"`
+	Events      []string  `db:"events"`
+	CreatedDate time.Time `db:"created_date"`
+}
+
+type WebhookRepository interface {
+	FindById(id uint64) (domain.Webhook, error)
+	FindByUserId(userId uint64) ([]domain.Webhook, error)
+	FindByUserIdAndEvent(userId uint64, eventType string) ([]domain.Webhook, error)
+	Save(webhook domain.Webhook) (domain.Webhook, error)
+	Delete(id uint64) error
+}
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return webhookRepository{db: db}
+}
+
+func (wr webhookRepository) FindById(id uint64) (domain.Webhook, error) {
+	webhookModel := webhook{}
+	sqlCommand := `SELECT id, user_id, url, secret, events, created_date FROM webhooks WHERE id = $1`
+	err := wr.db.QueryRow(sqlCommand, id).Scan(
+		&webhookModel.Id,
+		&webhookModel.UserId,
+		&webhookModel.Url,
+		&webhookModel.Secret,
+		pq.Array(&webhookModel.Events),
+		&webhookModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return wr.modelToDomain(webhookModel), nil
+}
+
+func (wr webhookRepository) FindByUserId(userId uint64) ([]domain.Webhook, error) {
+	sqlCommand := `SELECT id, user_id, url, secret, events, created_date FROM webhooks WHERE user_id = $1 ORDER BY id`
+	rows, err := wr.db.Query(sqlCommand, userId)
+	if err != nil {
+		return []domain.Webhook{}, err
+	}
+	defer rows.Close()
+
+	return wr.scanRows(rows)
+}
+
+func (wr webhookRepository) FindByUserIdAndEvent(userId uint64, eventType string) ([]domain.Webhook, error) {
+	sqlCommand := `SELECT id, user_id, url, secret, events, created_date FROM webhooks 
+	WHERE user_id = $1 AND $2 = ANY(events) ORDER BY id`
+	rows, err := wr.db.Query(sqlCommand, userId, eventType)
+	if err != nil {
+		return []domain.Webhook{}, err
+	}
+	defer rows.Close()
+
+	return wr.scanRows(rows)
+}
+
+func (wr webhookRepository) Save(webhook domain.Webhook) (domain.Webhook, error) {
+	webhookModel := wr.domainToModel(webhook)
+	sqlCommand := `INSERT INTO webhooks (user_id, url, secret, events) VALUES ($1, $2, $3, $4) RETURNING id, created_date`
+	err := wr.db.QueryRow(
+		sqlCommand,
+		webhookModel.UserId,
+		webhookModel.Url,
+		webhookModel.Secret,
+		pq.Array(webhookModel.Events),
+	).Scan(
+		&webhookModel.Id,
+		&webhookModel.CreatedDate,
+	)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return wr.modelToDomain(webhookModel), nil
+}
+
+func (wr webhookRepository) Delete(id uint64) error {
+	sqlCommand := `DELETE FROM webhooks WHERE id = $1`
+	_, err := wr.db.Exec(sqlCommand, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (wr webhookRepository) scanRows(rows *sql.Rows) ([]domain.Webhook, error) {
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		webhookModel := webhook{}
+		err := rows.Scan(
+			&webhookModel.Id,
+			&webhookModel.UserId,
+			&webhookModel.Url,
+			&webhookModel.Secret,
+			pq.Array(&webhookModel.Events),
+			&webhookModel.CreatedDate,
+		)
+		if err != nil {
+			return []domain.Webhook{}, err
+		}
+		webhooks = append(webhooks, wr.modelToDomain(webhookModel))
+	}
+	return webhooks, nil
+}
+
+func (wr webhookRepository) domainToModel(w domain.Webhook) webhook {
+	return webhook{
+		Id:          w.Id,
+		UserId:      w.UserId,
+		Url:         w.Url,
+		Secret:      w.Secret,
+		Events:      w.Events,
+		CreatedDate: w.CreatedDate,
+	}
+}
+
+func (wr webhookRepository) modelToDomain(w webhook) domain.Webhook {
+	return domain.Webhook{
+		Id:          w.Id,
+		UserId:      w.UserId,
+		Url:         w.Url,
+		Secret:      w.Secret,
+		Events:      w.Events,
+		CreatedDate: w.CreatedDate,
+	}
+}
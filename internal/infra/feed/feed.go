@@ -0,0 +1,177 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"strings"
+	"time"
+)
+
+const (
+	AtomContentType = "application/atom+xml; charset=utf-8"
+	RssContentType  = "application/rss+xml; charset=utf-8"
+)
+
+type Feed struct {
+	Title   string
+	Link    string
+	SelfUrl string
+	Updated time.Time
+	Entries []Entry
+}
+
+type Entry struct {
+	Id        string
+	Title     string
+	Link      string
+	Summary   string
+	Published time.Time
+	Updated   time.Time
+}
+
+func FromParties(title, selfUrl string, parties []domain.Party, frontendUrl string) Feed {
+	frontendUrl = strings.TrimSuffix(frontendUrl, "/")
+
+	f := Feed{
+		Title:   title,
+		Link:    frontendUrl,
+		SelfUrl: selfUrl,
+		Entries: make([]Entry, len(parties)),
+	}
+	for i, party := range parties {
+		link := fmt.Sprintf("%s/party/%d", frontendUrl, party.Id)
+		f.Entries[i] = Entry{
+			Id:        link,
+			Title:     party.Title,
+			Link:      link,
+			Summary:   partySummary(party),
+			Published: party.CreatedDate,
+			Updated:   party.UpdatedDate,
+		}
+		if party.UpdatedDate.After(f.Updated) {
+			f.Updated = party.UpdatedDate
+		}
+	}
+	return f
+}
+
+func partySummary(party domain.Party) string {
+	start := party.StartDate.In(party.Location()).Format("Mon, 02 Jan 2006 15:04 MST")
+	if party.Description == "" {
+		return start
+	}
+	return start + "\n\n" + party.Description
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Id      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Id        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+	Summary   string   `xml:"summary"`
+}
+
+func (f Feed) EncodeAtom() ([]byte, error) {
+	feed := atomFeed{
+		Id:      f.SelfUrl,
+		Title:   f.Title,
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.SelfUrl, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]atomEntry, len(f.Entries)),
+	}
+	for i, entry := range f.Entries {
+		feed.Entries[i] = atomEntry{
+			Id:        entry.Id,
+			Title:     entry.Title,
+			Link:      atomLink{Href: entry.Link, Rel: "alternate", Type: "text/html"},
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+			Summary:   entry.Summary,
+		}
+	}
+	return encode(feed)
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNs  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Guid        rssGuid `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGuid struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+func (f Feed) EncodeRss() ([]byte, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNs:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Title,
+			AtomLink:    atomLink{Href: f.SelfUrl, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, len(f.Entries)),
+		},
+	}
+	if !f.Updated.IsZero() {
+		feed.Channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for i, entry := range f.Entries {
+		feed.Channel.Items[i] = rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			Guid:        rssGuid{Value: entry.Id, IsPermaLink: true},
+			PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+			Description: entry.Summary,
+		}
+	}
+	return encode(feed)
+}
+
+func encode(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -2,11 +2,10 @@ package filesystem
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"go-rest-api/config"
+	"io"
 	"log"
-	"os"
 	"path"
 	"strings"
 
@@ -27,34 +26,20 @@ func NewCloudinaryService(config config.Configuration) *CloudinaryService {
 	return &CloudinaryService{cloudinaryObject: cloudinaryObject}
 }
 
-func (c *CloudinaryService) SaveImageToCloudinary(imageBase64 string, fileName string) (string, error) {
+func (c *CloudinaryService) Save(fileName string, content io.Reader) (string, error) {
 	ctx := context.Background()
-	decodedImage, err := base64.StdEncoding.DecodeString(imageBase64)
-	if err != nil {
-		return "", fmt.Errorf("cloudinaryService: failed to decode image: %w", err)
-	}
-
-	tempFile, err := os.CreateTemp("", fileName)
-	if err != nil {
-		return "", fmt.Errorf("cloudinaryService: failed to create temporary file: %w", err)
-	}
-	defer os.Remove(tempFile.Name())
-	defer tempFile.Close()
-
-	_, err = tempFile.Write(decodedImage)
-	if err != nil {
-		return "", fmt.Errorf("cloudinaryService: failed to write to temporary file: %w", err)
-	}
-
-	uploadResult, err := c.uploadImage(ctx, tempFile.Name())
+	uploadResult, err := c.cloudinaryObject.Upload.Upload(ctx, content, uploader.UploadParams{
+		PublicID: strings.TrimSuffix(fileName, path.Ext(fileName)),
+	})
 	if err != nil {
+		log.Printf("cloudinaryServiece.Save: %s", err)
 		return "", fmt.Errorf("cloudinaryService: failed to upload image to Cloudinary: %w", err)
 	}
 
 	return uploadResult.SecureURL, nil
 }
 
-func (c *CloudinaryService) DeleteImage(imageUrl string) error {
+func (c *CloudinaryService) Delete(imageUrl string) error {
 	ctx := context.Background()
 	publicId := c.imageUrlToImagePublicId(imageUrl)
 	_, err := c.cloudinaryObject.Upload.Destroy(ctx, uploader.DestroyParams{
@@ -68,15 +53,6 @@ func (c *CloudinaryService) DeleteImage(imageUrl string) error {
 	return nil
 }
 
-func (c *CloudinaryService) uploadImage(ctx context.Context, filePath string) (*uploader.UploadResult, error) {
-	result, err := c.cloudinaryObject.Upload.Upload(ctx, filePath, uploader.UploadParams{})
-	if err != nil {
-		log.Printf("cloudinaryServiece.uploadImage: %s", err)
-		return nil, err
-	}
-	return result, nil
-}
-
 func (c *CloudinaryService) imageUrlToImagePublicId(imageUrl string) string {
 	filePath := path.Base(imageUrl)
 	imagePublicId := strings.TrimSuffix(filePath, path.Ext(filePath))
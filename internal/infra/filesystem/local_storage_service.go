@@ -0,0 +1,62 @@
+package filesystem
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+type localStorage struct {
+	loc     string
+	baseUrl string
+}
+
+func NewLocalStorage(location, baseUrl string) ImageStorage {
+	rootDir, err := os.Getwd()
+	if err != nil {
+		log.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	absLocation := path.Join(rootDir, location)
+
+	err = os.MkdirAll(absLocation, os.ModePerm)
+	if err != nil {
+		log.Fatalf("Failed to create directory: %v", err)
+	}
+
+	return localStorage{
+		loc:     absLocation,
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+	}
+}
+
+func (s localStorage) Save(fileName string, content io.Reader) (string, error) {
+	fileName = path.Base(fileName)
+
+	file, err := os.Create(path.Join(s.loc, fileName))
+	if err != nil {
+		return "", fmt.Errorf("localStorage: failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("localStorage: failed to write file: %w", err)
+	}
+
+	return s.baseUrl + "/" + fileName, nil
+}
+
+// Only the last path segment is used so a URL can't point outside the directory.
+func (s localStorage) Delete(imageUrl string) error {
+	err := os.Remove(path.Join(s.loc, path.Base(imageUrl)))
+	if err != nil {
+		log.Printf("localStorage.Delete: %s", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package filesystem
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service          = "s3"
+	s3Algorithm        = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	s3SignedHeaders    = "host;x-amz-content-sha256;x-amz-date"
+)
+
+type s3Storage struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicUrl string
+	client    *http.Client
+}
+
+func NewS3Storage(cfg config.Configuration) (ImageStorage, error) {
+	if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+		return nil, errors.New("s3Storage: S3_ENDPOINT and S3_BUCKET are required")
+	}
+
+	endpoint := strings.TrimSuffix(cfg.S3Endpoint, "/")
+	publicUrl := strings.TrimSuffix(cfg.S3PublicUrl, "/")
+	if publicUrl == "" {
+		publicUrl = endpoint + "/" + cfg.S3Bucket
+	}
+
+	return s3Storage{
+		endpoint:  endpoint,
+		region:    cfg.S3Region,
+		bucket:    cfg.S3Bucket,
+		accessKey: cfg.S3AccessKey,
+		secretKey: cfg.S3SecretKey,
+		publicUrl: publicUrl,
+		client:    &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// S3 needs the length of the object up front.
+func (s s3Storage) Save(fileName string, content io.Reader) (string, error) {
+	key := path.Base(fileName)
+
+	tempFile, err := os.CreateTemp("", "s3-upload-*")
+	if err != nil {
+		return "", fmt.Errorf("s3Storage: failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	size, err := io.Copy(tempFile, content)
+	if err != nil {
+		return "", fmt.Errorf("s3Storage: failed to buffer upload: %w", err)
+	}
+
+	head := make([]byte, 512)
+	n, _ := tempFile.ReadAt(head, 0)
+	_, err = tempFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", fmt.Errorf("s3Storage: failed to rewind upload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.objectUrl(key), tempFile)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", http.DetectContentType(head[:n]))
+
+	err = s.do(req, s3UnsignedPayload)
+	if err != nil {
+		return "", fmt.Errorf("s3Storage: failed to upload %s: %w", key, err)
+	}
+
+	return s.publicUrl + "/" + key, nil
+}
+
+func (s s3Storage) Delete(imageUrl string) error {
+	req, err := http.NewRequest(http.MethodDelete, s.objectUrl(path.Base(imageUrl)), nil)
+	if err != nil {
+		return err
+	}
+
+	err = s.do(req, s3EmptyPayloadHash)
+	if err != nil {
+		log.Printf("s3Storage.Delete: %s", err)
+		return err
+	}
+	return nil
+}
+
+func (s s3Storage) objectUrl(key string) string {
+	return s.endpoint + "/" + url.PathEscape(s.bucket) + "/" + url.PathEscape(key)
+}
+
+func (s s3Storage) do(req *http.Request, payloadHash string) error {
+	s.sign(req, payloadHash, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+func (s s3Storage) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		s3SignedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/" + s3Service + "/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := hmacSha256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSha256(key, s.region)
+	key = hmacSha256(key, s3Service)
+	key = hmacSha256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, s3SignedHeaders, signature,
+	))
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
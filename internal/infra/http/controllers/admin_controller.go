@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+	"strconv"
+)
+
+type AdminController struct {
+	adminService app.AdminService
+}
+
+func NewAdminController(as app.AdminService) AdminController {
+	return AdminController{
+		adminService: as,
+	}
+}
+
+func (c AdminController) FindUsers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		limit := r.URL.Query().Get("limit")
+		if page == "" || limit == "" {
+			BadRequest(w, errors.New("invalid page or limit"))
+			return
+		}
+		numericPage, pErr := strconv.ParseInt(page, 10, 32)
+		numericLimit, lErr := strconv.ParseInt(limit, 10, 32)
+		if pErr != nil || lErr != nil {
+			BadRequest(w, errors.New("invalid page or limit"))
+			return
+		}
+
+		users, err := c.adminService.SearchUsers(r.URL.Query().Get("search"), int32(numericPage), int32(numericLimit))
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.AdminUserDto{}.DomainToDtoCollection(users))
+	}
+}
+
+func (c AdminController) FindUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetPathValueFromCtx[domain.User](r.Context())
+		Success(w, resources.AdminUserDto{}.DomainToDto(user))
+	}
+}
+
+func (c AdminController) Ban() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Context().Value(UserKey).(domain.User)
+		user := GetPathValueFromCtx[domain.User](r.Context())
+		ban, err := requests.Bind(r, requests.BanUserRequest{}, domain.UserBan{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		user, err = c.adminService.Ban(actor, user, ban)
+		if err != nil {
+			c.handleManageError(w, err)
+			return
+		}
+
+		Success(w, resources.AdminUserDto{}.DomainToDto(user))
+	}
+}
+
+func (c AdminController) Unban() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Context().Value(UserKey).(domain.User)
+		user := GetPathValueFromCtx[domain.User](r.Context())
+
+		user, err := c.adminService.Unban(actor, user)
+		if err != nil {
+			c.handleManageError(w, err)
+			return
+		}
+
+		Success(w, resources.AdminUserDto{}.DomainToDto(user))
+	}
+}
+
+func (c AdminController) ChangeRole() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Context().Value(UserKey).(domain.User)
+		user := GetPathValueFromCtx[domain.User](r.Context())
+		change, err := requests.Bind(r, requests.ChangeRoleRequest{}, domain.RoleChange{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		user, err = c.adminService.ChangeRole(actor, user, change)
+		if err != nil {
+			c.handleManageError(w, err)
+			return
+		}
+
+		Success(w, resources.AdminUserDto{}.DomainToDto(user))
+	}
+}
+
+func (c AdminController) AdjustBalance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Context().Value(UserKey).(domain.User)
+		user := GetPathValueFromCtx[domain.User](r.Context())
+		adjustment, err := requests.Bind(r, requests.AdjustBalanceRequest{}, domain.BalanceAdjustment{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		transaction, err := c.adminService.AdjustBalance(actor, user, adjustment)
+		if err != nil {
+			if errors.Is(err, repositories.ErrInsufficientFunds) || errors.Is(err, app.ErrZeroBalanceAmount) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Created(w, resources.PointTransactionDto{}.DomainToDto(transaction))
+	}
+}
+
+func (c AdminController) handleManageError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrInvalidRole), errors.Is(err, app.ErrCannotManageSelf):
+		BadRequest(w, err)
+	case errors.Is(err, app.ErrInsufficientRole):
+		Forbidden(w, err)
+	default:
+		InternalServerError(w, err)
+	}
+}
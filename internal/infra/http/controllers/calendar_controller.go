@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/calendar"
+	"go-rest-api/internal/infra/http/resources"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CalendarController struct {
+	calendarService app.CalendarService
+	frontendUrl     string
+}
+
+func NewCalendarController(calendarService app.CalendarService, frontendUrl string) CalendarController {
+	return CalendarController{
+		calendarService: calendarService,
+		frontendUrl:     frontendUrl,
+	}
+}
+
+func (c CalendarController) PartyEvent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		cal := calendar.FromParties(party.Title, []domain.Party{party}, c.frontendUrl)
+		writeCalendar(w, cal, fmt.Sprintf("party-%d.ics", party.Id))
+	}
+}
+
+func (c CalendarController) SaveFeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		feed, err := c.calendarService.SaveFeed(user.Id)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Created(w, resources.CalendarFeedDto{}.DomainToDto(feed))
+	}
+}
+
+func (c CalendarController) DeleteFeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		err := c.calendarService.DeleteFeed(user.Id)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
+
+func (c CalendarController) Feed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		feed, err := c.calendarService.FindFeed(chi.URLParam(r, "token"))
+		if err != nil {
+			if errors.Is(err, app.ErrCalendarFeedNotFound) {
+				NotFound(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		parties, err := c.calendarService.FindUserParties(feed.UserId)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		writeCalendar(w, calendar.FromParties("My parties", parties, c.frontendUrl), "parties.ics")
+	}
+}
+
+func writeCalendar(w http.ResponseWriter, cal calendar.Calendar, fileName string) {
+	w.Header().Set("Content-Type", calendar.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", fileName))
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(cal.Encode())
+	if err != nil {
+		log.Print(err)
+	}
+}
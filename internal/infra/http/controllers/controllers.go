@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"go-rest-api/internal/domain"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type ctxKey struct {
@@ -18,6 +22,10 @@ var (
 	UserKey    = ctxKey{"user"}
 	SessionKey = ctxKey{"session"}
 	PartyKey   = ctxKey{"party"}
+	WebhookKey = ctxKey{"webhook"}
+	TokenKey   = ctxKey{"token"}
+	TopUpKey   = ctxKey{"topUp"}
+	SeriesKey  = ctxKey{"series"}
 )
 
 func GetPathValueInCtx[T any](ctx context.Context, value T) context.Context {
@@ -36,13 +44,34 @@ func (k CtxStrKey) UrlParam() string {
 
 func ResolveCtxKeyFromPathType(value any) CtxStrKey {
 	switch value.(type) {
+	case *domain.User:
+		return CtxStrKey(UserKey.name)
 	case *domain.Party:
 		return CtxStrKey(PartyKey.name)
+	case *domain.Webhook:
+		return CtxStrKey(WebhookKey.name)
+	case *domain.PersonalAccessToken:
+		return CtxStrKey(TokenKey.name)
+	case *domain.TopUp:
+		return CtxStrKey(TopUpKey.name)
+	case *domain.PartySeries:
+		return CtxStrKey(SeriesKey.name)
 	default:
 		panic("unk type in resolveCtxKeyFromPathType (controller)")
 	}
 }
 
+func ClientInfoFromRequest(r *http.Request) domain.ClientInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return domain.ClientInfo{
+		UserAgent: r.UserAgent(),
+		Ip:        ip,
+	}
+}
+
 func Ok(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -110,6 +139,35 @@ func Forbidden(w http.ResponseWriter, err error) {
 	encodeErrorData(w, err)
 }
 
+func RequestEntityTooLarge(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+	encodeErrorData(w, err)
+}
+
+func UnsupportedMediaType(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnsupportedMediaType)
+
+	encodeErrorData(w, err)
+}
+
+func ServiceUnavailable(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+
+	encodeErrorData(w, err)
+}
+
+func TooManyRequests(w http.ResponseWriter, err error, retryAfter time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	encodeErrorData(w, err)
+}
+
 func encodeErrorData(w http.ResponseWriter, err error) {
 	e := json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 	if e != nil {
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/feed"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	feedLimit  = 50
+	feedMaxAge = 5 * time.Minute
+)
+
+type FeedController struct {
+	partyService app.PartyService
+	userService  app.UserService
+	apiUrl       string
+	frontendUrl  string
+}
+
+func NewFeedController(partyService app.PartyService, userService app.UserService, apiUrl, frontendUrl string) FeedController {
+	return FeedController{
+		partyService: partyService,
+		userService:  userService,
+		apiUrl:       strings.TrimSuffix(apiUrl, "/"),
+		frontendUrl:  frontendUrl,
+	}
+}
+
+func (c FeedController) Parties() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parties, err := c.partyService.GetParties(domain.PartyFilter{Upcoming: true}, 1, feedLimit)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		f := feed.FromParties("Upcoming parties", c.apiUrl+r.URL.Path, parties.Parties, c.frontendUrl)
+		c.writeFeed(w, r, f)
+	}
+}
+
+func (c FeedController) Creator() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		creatorId, err := strconv.ParseUint(chi.URLParam(r, "creatorId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid creatorId"))
+			return
+		}
+
+		creator, err := c.userService.FindById(creatorId)
+		if err != nil {
+			NotFound(w, err)
+			return
+		}
+
+		parties, err := c.partyService.FindByCreatorId(creator.Id, domain.PartyFilter{Upcoming: true}, 1, feedLimit)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		f := feed.FromParties("Upcoming parties by "+creator.Name, c.apiUrl+r.URL.Path, parties.Parties, c.frontendUrl)
+		c.writeFeed(w, r, f)
+	}
+}
+
+// The ETag is derived from the body so deleted parties change it too.
+func (c FeedController) writeFeed(w http.ResponseWriter, r *http.Request, f feed.Feed) {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+	if chi.URLParam(r, "format") == "rss" {
+		body, err = f.EncodeRss()
+		contentType = feed.RssContentType
+	} else {
+		body, err = f.EncodeAtom()
+		contentType = feed.AtomContentType
+	}
+	if err != nil {
+		InternalServerError(w, err)
+		return
+	}
+
+	hash := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(feedMaxAge.Seconds())))
+	if !f.Updated.IsZero() {
+		w.Header().Set("Last-Modified", f.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if isNotModified(r, etag, f.Updated) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(body)
+	if err != nil {
+		log.Print(err)
+	}
+}
+
+func isNotModified(r *http.Request, etag string, updated time.Time) bool {
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if updated.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have second precision.
+	return !updated.Truncate(time.Second).After(since)
+}
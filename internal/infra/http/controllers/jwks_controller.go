@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"go-rest-api/internal/infra/tokens"
+	"net/http"
+)
+
+type JwksController struct {
+	tokenAuth *tokens.JWTAuth
+}
+
+func NewJwksController(tokenAuth *tokens.JWTAuth) JwksController {
+	return JwksController{tokenAuth: tokenAuth}
+}
+
+func (c JwksController) Jwks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		Success(w, c.tokenAuth.PublicKeys())
+	}
+}
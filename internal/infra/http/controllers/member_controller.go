@@ -4,6 +4,8 @@ import (
 	"errors"
 	"go-rest-api/internal/app"
 	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/http/requests"
 	"go-rest-api/internal/infra/http/resources"
 	"net/http"
 	"strconv"
@@ -49,9 +51,16 @@ func (m MemberController) Save() http.HandlerFunc {
 			return
 		}
 
+		join, err := requests.Bind(r, requests.JoinPartyRequest{}, domain.PartyJoin{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
 		domainMember := domain.Member{
 			PartyId: numericPartyId,
 			UserId:  domainUser.Id,
+			TierId:  join.TierId,
 		}
 
 		err = m.memberService.Exists(domainMember)
@@ -60,9 +69,22 @@ func (m MemberController) Save() http.HandlerFunc {
 			return
 		}
 
-		err = m.memberService.Save(domainMember)
+		_, err = m.memberService.Save(domainMember, join.PromoCode)
 		if err != nil {
-			InternalServerError(w, err)
+			switch {
+			case errors.Is(err, app.ErrEmailNotVerified):
+				Forbidden(w, err)
+			case errors.Is(err, app.ErrPromoCodeNotFound),
+				errors.Is(err, app.ErrTierRequired),
+				errors.Is(err, app.ErrTierNotFound),
+				errors.Is(err, repositories.ErrTierSoldOut),
+				errors.Is(err, repositories.ErrPromoCodeUnavailable),
+				errors.Is(err, repositories.ErrPromoCodeRedeemed),
+				errors.Is(err, repositories.ErrInsufficientFunds):
+				BadRequest(w, err)
+			default:
+				InternalServerError(w, err)
+			}
 			return
 		}
 
@@ -130,6 +152,10 @@ func (m MemberController) Delete() http.HandlerFunc {
 
 		err = m.memberService.Delete(domainMember)
 		if err != nil {
+			if errors.Is(err, repositories.ErrInsufficientFunds) {
+				BadRequest(w, err)
+				return
+			}
 			InternalServerError(w, err)
 			return
 		}
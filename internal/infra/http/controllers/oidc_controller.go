@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/api/v1/auth/oidc"
+	oidcStateCookieTTL  = 10 * time.Minute
+)
+
+type OidcController struct {
+	oidcServ     app.OidcService
+	frontendUrl  string
+	secureCookie bool
+}
+
+func NewOidcController(oidcServ app.OidcService, apiUrl, frontendUrl string) OidcController {
+	return OidcController{
+		oidcServ:     oidcServ,
+		frontendUrl:  strings.TrimSuffix(frontendUrl, "/"),
+		secureCookie: strings.HasPrefix(apiUrl, "https://"),
+	}
+}
+
+func (c OidcController) Login() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authUrl, state, err := c.oidcServ.AuthorizationUrl(chi.URLParam(r, "provider"))
+		if err != nil {
+			if errors.Is(err, app.ErrUnknownOidcProvider) {
+				NotFound(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		c.setStateCookie(w, state, oidcStateCookieTTL)
+		http.Redirect(w, r, authUrl, http.StatusFound)
+	}
+}
+
+func (c OidcController) Callback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.setStateCookie(w, "", -1)
+
+		query := r.URL.Query()
+		if providerErr := query.Get("error"); providerErr != "" {
+			c.redirect(w, r, url.Values{"error": {providerErr}})
+			return
+		}
+		if query.Get("code") == "" || query.Get("state") == "" {
+			c.redirect(w, r, url.Values{"error": {"code and state are required"}})
+			return
+		}
+
+		var browserState string
+		if cookie, err := r.Cookie(oidcStateCookie); err == nil {
+			browserState = cookie.Value
+		}
+
+		code, err := c.oidcServ.Callback(domain.OidcCallback{
+			Provider:     chi.URLParam(r, "provider"),
+			Code:         query.Get("code"),
+			State:        query.Get("state"),
+			BrowserState: browserState,
+		})
+		if err != nil {
+			c.redirect(w, r, url.Values{"error": {err.Error()}})
+			return
+		}
+
+		c.redirect(w, r, url.Values{"code": {code}})
+	}
+}
+
+func (c OidcController) Exchange() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exchange, err := requests.Bind(r, requests.OidcExchangeRequest{}, domain.OidcExchange{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		user, authTokens, err := c.oidcServ.Exchange(exchange, ClientInfoFromRequest(r))
+		if err != nil {
+			switch {
+			case errors.Is(err, app.ErrInvalidToken):
+				Unauthorized(w, err)
+			case errors.Is(err, app.ErrUserBanned):
+				Forbidden(w, err)
+			default:
+				InternalServerError(w, err)
+			}
+			return
+		}
+
+		var sessDto resources.SessionDto
+		Success(w, sessDto.DomainToDto(authTokens, user))
+	}
+}
+
+func (c OidcController) setStateCookie(w http.ResponseWriter, state string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     oidcStateCookiePath,
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   c.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (c OidcController) redirect(w http.ResponseWriter, r *http.Request, query url.Values) {
+	http.Redirect(w, r, c.frontendUrl+"/auth/oidc/callback?"+query.Encode(), http.StatusFound)
+}
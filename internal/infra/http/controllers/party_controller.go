@@ -4,6 +4,7 @@ import (
 	"errors"
 	"go-rest-api/internal/app"
 	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
 	"go-rest-api/internal/infra/http/requests"
 	"go-rest-api/internal/infra/http/resources"
 	"log"
@@ -60,7 +61,7 @@ func (p PartyController) FindById() http.HandlerFunc {
 
 		memberDto := resources.MemberDto{}
 		partyDto := resources.PartyWithMembersDto{}
-		Success(w, partyDto.DomainPartyWithMembersToDto(domainParty, memberDto.DomainToDto(domainUser), memberDto.DomainToDtoCollection(domainPartyMembers)))
+		Success(w, partyDto.DomainPartyWithMembersToDto(domainParty, memberDto.DomainToDto(domainUser), resources.PartyMemberDto{}.DomainToDtoCollection(domainPartyMembers, domainParty.Tiers)))
 	}
 }
 
@@ -82,7 +83,7 @@ func (p PartyController) FindByCreatorId() http.HandlerFunc {
 			return
 		}
 
-		domainParties, err := p.partyService.FindByCreatorId(numericCreatorId, int32(numericPage), int32(numericLimit))
+		domainParties, err := p.partyService.FindByCreatorId(numericCreatorId, partyFilterFromRequest(r), int32(numericPage), int32(numericLimit))
 		if err != nil {
 			NotFound(w, err)
 			return
@@ -161,7 +162,7 @@ func (p PartyController) GetParties() http.HandlerFunc {
 			return
 		}
 
-		domainParties, err := p.partyService.GetParties(int32(numericPage), int32(numericLimit))
+		domainParties, err := p.partyService.GetParties(partyFilterFromRequest(r), int32(numericPage), int32(numericLimit))
 		if err != nil {
 			NotFound(w, err)
 			return
@@ -194,7 +195,7 @@ func (p PartyController) Save() http.HandlerFunc {
 			return
 		}
 
-		if domainParty.Price < 1 {
+		if !domainParty.HasTiers() && domainParty.Price < 1 {
 			BadRequest(w, errors.New("the price cannot be lower than 1"))
 			return
 		}
@@ -208,6 +209,20 @@ func (p PartyController) Save() http.HandlerFunc {
 
 		domainParty, err = p.partyService.Save(domainParty)
 		if err != nil {
+			if errors.Is(err, app.ErrEmailNotVerified) {
+				Forbidden(w, err)
+				return
+			}
+			if errors.Is(err, repositories.ErrTierExists) ||
+				errors.Is(err, repositories.ErrInsufficientFunds) ||
+				errors.Is(err, domain.ErrInvalidRecurrence) ||
+				errors.Is(err, app.ErrStartDateInPast) ||
+				errors.Is(err, app.ErrUploadNotFound) ||
+				errors.Is(err, app.ErrInvalidEndDate) ||
+				errors.Is(err, app.ErrInvalidTimezone) {
+				BadRequest(w, err)
+				return
+			}
 			log.Printf("Party controller: save %s", err)
 			InternalServerError(w, err)
 			return
@@ -228,7 +243,7 @@ func (p PartyController) Save() http.HandlerFunc {
 
 		memberDto := resources.MemberDto{}
 		partyDto := resources.PartyWithMembersDto{}
-		Success(w, partyDto.DomainPartyWithMembersToDto(domainParty, memberDto.DomainToDto(domainUser), memberDto.DomainToDtoCollection(domainPartyMembers)))
+		Success(w, partyDto.DomainPartyWithMembersToDto(domainParty, memberDto.DomainToDto(domainUser), resources.PartyMemberDto{}.DomainToDtoCollection(domainPartyMembers, domainParty.Tiers)))
 	}
 }
 
@@ -263,8 +278,15 @@ func (p PartyController) Update() http.HandlerFunc {
 		newPartyDomain.Id = numericPartyId
 		newPartyDomain.CreatorId = creatorUser.Id
 
-		domainParty, err := p.partyService.Update(newPartyDomain)
+		domainParty, err := p.partyService.Update(newPartyDomain, r.URL.Query().Get("scope"))
 		if err != nil {
+			if errors.Is(err, app.ErrInvalidSeriesScope) ||
+				errors.Is(err, app.ErrUploadNotFound) ||
+				errors.Is(err, app.ErrInvalidEndDate) ||
+				errors.Is(err, app.ErrInvalidTimezone) {
+				BadRequest(w, err)
+				return
+			}
 			InternalServerError(w, err)
 			return
 		}
@@ -283,7 +305,7 @@ func (p PartyController) Update() http.HandlerFunc {
 
 		memberDto := resources.MemberDto{}
 		partyDto := resources.PartyWithMembersDto{}
-		Success(w, partyDto.DomainPartyWithMembersToDto(domainParty, memberDto.DomainToDto(domainUser), memberDto.DomainToDtoCollection(domainPartyMembers)))
+		Success(w, partyDto.DomainPartyWithMembersToDto(domainParty, memberDto.DomainToDto(domainUser), resources.PartyMemberDto{}.DomainToDtoCollection(domainPartyMembers, domainParty.Tiers)))
 	}
 }
 
@@ -303,6 +325,10 @@ func (p PartyController) Delete() http.HandlerFunc {
 
 		err = p.partyService.Delete(numericPartyId)
 		if err != nil {
+			if errors.Is(err, repositories.ErrInsufficientFunds) {
+				BadRequest(w, err)
+				return
+			}
 			InternalServerError(w, err)
 			return
 		}
@@ -310,3 +336,10 @@ func (p PartyController) Delete() http.HandlerFunc {
 		Ok(w)
 	}
 }
+
+func partyFilterFromRequest(r *http.Request) domain.PartyFilter {
+	return domain.PartyFilter{
+		HappeningNow: r.URL.Query().Get("happening") == "now",
+		Upcoming:     r.URL.Query().Get("upcoming") == "true",
+	}
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PartyImageController struct {
+	imageService app.PartyImageService
+}
+
+func NewPartyImageController(imageService app.PartyImageService) PartyImageController {
+	return PartyImageController{
+		imageService: imageService,
+	}
+}
+
+func (c PartyImageController) FindImages() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		images, err := c.imageService.FindByPartyId(party.Id, domain.PartyImageKindHost, false)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.PartyImageDto{}.DomainToDtoCollection(images))
+	}
+}
+
+func (c PartyImageController) SaveImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		image, err := requests.Bind(r, requests.CreatePartyImageRequest{}, domain.PartyImage{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		image, err = c.imageService.SaveHostImage(party, image)
+		if err != nil {
+			c.handleImageError(w, err)
+			return
+		}
+
+		Created(w, resources.PartyImageDto{}.DomainToDto(image))
+	}
+}
+
+func (c PartyImageController) Reorder() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		ids, err := requests.Bind(r, requests.ReorderPartyImagesRequest{}, []uint64{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		images, err := c.imageService.Reorder(party.Id, ids)
+		if err != nil {
+			c.handleImageError(w, err)
+			return
+		}
+
+		Success(w, resources.PartyImageDto{}.DomainToDtoCollection(images))
+	}
+}
+
+func (c PartyImageController) SetCover() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+		imageId, err := strconv.ParseUint(chi.URLParam(r, "imageId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid imageId"))
+			return
+		}
+
+		images, err := c.imageService.SetCover(party.Id, imageId)
+		if err != nil {
+			c.handleImageError(w, err)
+			return
+		}
+
+		Success(w, resources.PartyImageDto{}.DomainToDtoCollection(images))
+	}
+}
+
+func (c PartyImageController) DeleteImage() http.HandlerFunc {
+	return c.delete(domain.PartyImageKindHost)
+}
+
+func (c PartyImageController) FindGallery() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		images, err := c.imageService.FindByPartyId(party.Id, domain.PartyImageKindGallery, user.Id == party.CreatorId)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.PartyImageDto{}.DomainToDtoCollection(images))
+	}
+}
+
+func (c PartyImageController) SavePhoto() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		image, err := requests.Bind(r, requests.CreatePartyImageRequest{}, domain.PartyImage{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		image.UserId = user.Id
+
+		image, err = c.imageService.SaveGalleryPhoto(party, image)
+		if err != nil {
+			c.handleImageError(w, err)
+			return
+		}
+
+		Created(w, resources.PartyImageDto{}.DomainToDto(image))
+	}
+}
+
+func (c PartyImageController) ApprovePhoto() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+		imageId, err := strconv.ParseUint(chi.URLParam(r, "imageId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid imageId"))
+			return
+		}
+
+		image, err := c.imageService.Approve(party.Id, imageId)
+		if err != nil {
+			c.handleImageError(w, err)
+			return
+		}
+
+		Success(w, resources.PartyImageDto{}.DomainToDto(image))
+	}
+}
+
+func (c PartyImageController) DeletePhoto() http.HandlerFunc {
+	return c.delete(domain.PartyImageKindGallery)
+}
+
+func (c PartyImageController) delete(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+		imageId, err := strconv.ParseUint(chi.URLParam(r, "imageId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid imageId"))
+			return
+		}
+
+		err = c.imageService.Delete(party, kind, user.Id, imageId)
+		if err != nil {
+			c.handleImageError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
+
+func (c PartyImageController) handleImageError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrImageNotFound):
+		NotFound(w, err)
+	case errors.Is(err, app.ErrNotPartyAttendee),
+		errors.Is(err, app.ErrImageForbidden):
+		Forbidden(w, err)
+	case errors.Is(err, app.ErrTooManyImages),
+		errors.Is(err, app.ErrGalleryFull),
+		errors.Is(err, app.ErrImageOrderMismatch),
+		errors.Is(err, app.ErrGalleryNotOpen),
+		errors.Is(err, app.ErrUploadNotFound):
+		BadRequest(w, err)
+	default:
+		InternalServerError(w, err)
+	}
+}
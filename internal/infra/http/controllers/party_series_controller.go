@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+)
+
+type PartySeriesController struct {
+	seriesService app.PartySeriesService
+	userService   app.UserService
+}
+
+func NewPartySeriesController(seriesService app.PartySeriesService, userService app.UserService) PartySeriesController {
+	return PartySeriesController{
+		seriesService: seriesService,
+		userService:   userService,
+	}
+}
+
+func (c PartySeriesController) FindById() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		series := GetPathValueFromCtx[domain.PartySeries](r.Context())
+
+		creator, err := c.userService.FindById(series.CreatorId)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.PartySeriesDto{}.DomainToDto(series, resources.MemberDto{}.DomainToDto(creator)))
+	}
+}
+
+func (c PartySeriesController) Cancel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		series := GetPathValueFromCtx[domain.PartySeries](r.Context())
+
+		_, err := c.seriesService.Cancel(series)
+		if err != nil {
+			if errors.Is(err, repositories.ErrInsufficientFunds) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
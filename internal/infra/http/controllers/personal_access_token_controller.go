@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+)
+
+type PersonalAccessTokenController struct {
+	tokenService app.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenController(tokenService app.PersonalAccessTokenService) PersonalAccessTokenController {
+	return PersonalAccessTokenController{
+		tokenService: tokenService,
+	}
+}
+
+func (c PersonalAccessTokenController) Save() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		token, err := requests.Bind(r, requests.CreatePersonalAccessTokenRequest{}, domain.PersonalAccessToken{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		token.UserId = user.Id
+
+		token, err = c.tokenService.Save(token)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Created(w, resources.PersonalAccessTokenDto{}.DomainToDtoWithToken(token))
+	}
+}
+
+func (c PersonalAccessTokenController) FindMy() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		tokens, err := c.tokenService.FindByUserId(user.Id)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.PersonalAccessTokenDto{}.DomainToDtoCollection(tokens))
+	}
+}
+
+func (c PersonalAccessTokenController) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := GetPathValueFromCtx[domain.PersonalAccessToken](r.Context())
+
+		err := c.tokenService.Delete(token.Id)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
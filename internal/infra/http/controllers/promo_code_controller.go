@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PromoCodeController struct {
+	promoCodeService app.PromoCodeService
+}
+
+func NewPromoCodeController(promoCodeService app.PromoCodeService) PromoCodeController {
+	return PromoCodeController{
+		promoCodeService: promoCodeService,
+	}
+}
+
+func (c PromoCodeController) FindByParty() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		promoCodes, err := c.promoCodeService.FindByPartyId(party.Id)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.PromoCodeDto{}.DomainToDtoCollection(promoCodes))
+	}
+}
+
+func (c PromoCodeController) Save() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		promoCode, err := requests.Bind(r, requests.CreatePromoCodeRequest{}, domain.PromoCode{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		promoCode.PartyId = party.Id
+
+		promoCode, err = c.promoCodeService.Save(promoCode)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidDiscount) ||
+				errors.Is(err, app.ErrInvalidValidPeriod) ||
+				errors.Is(err, repositories.ErrPromoCodeExists) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Created(w, resources.PromoCodeDto{}.DomainToDto(promoCode))
+	}
+}
+
+func (c PromoCodeController) FindRedemptions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+		promoCodeId, err := strconv.ParseUint(chi.URLParam(r, "promoCodeId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid promoCodeId"))
+			return
+		}
+
+		redemptions, err := c.promoCodeService.FindRedemptions(party.Id, promoCodeId)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.PromoRedemptionDto{}.DomainToDtoCollection(redemptions))
+	}
+}
+
+func (c PromoCodeController) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+		promoCodeId, err := strconv.ParseUint(chi.URLParam(r, "promoCodeId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid promoCodeId"))
+			return
+		}
+
+		err = c.promoCodeService.Delete(party.Id, promoCodeId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				NotFound(w, app.ErrPromoCodeNotFound)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
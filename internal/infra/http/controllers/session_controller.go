@@ -8,6 +8,9 @@ import (
 	"go-rest-api/internal/infra/http/requests"
 	"go-rest-api/internal/infra/http/resources"
 	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 type SessionController struct {
@@ -27,14 +30,14 @@ func (c SessionController) Register() http.HandlerFunc {
 			return
 		}
 
-		user, token, err := c.sessionServ.Register(user)
+		user, authTokens, err := c.sessionServ.Register(user, ClientInfoFromRequest(r))
 		if err != nil {
 			BadRequest(w, err)
 			return
 		}
 
 		var sessDto resources.SessionDto
-		Success(w, sessDto.DomainToDto(token, user))
+		Success(w, sessDto.DomainToDto(authTokens, user))
 	}
 }
 
@@ -45,18 +48,82 @@ func (c SessionController) Login() http.HandlerFunc {
 			BadRequest(w, errors.New("invalid request body"))
 			return
 		}
-		user, token, err := c.sessionServ.Login(domainUser)
+		user, authTokens, err := c.sessionServ.Login(domainUser, ClientInfoFromRequest(r))
 		if err != nil {
-			if err == sql.ErrNoRows {
-				NotFound(w, err)
+			var throttled app.LoginThrottledError
+			if errors.As(err, &throttled) {
+				TooManyRequests(w, err, throttled.RetryAfter)
+				return
+			}
+			if errors.Is(err, app.ErrInvalidCredentials) {
+				Unauthorized(w, err)
 				return
 			}
+			if errors.Is(err, app.ErrUserBanned) {
+				Forbidden(w, err)
+				return
+			}
+
+			InternalServerError(w, err)
+			return
+		}
+		var sessDto resources.SessionDto
+		Success(w, sessDto.DomainToDto(authTokens, user))
+	}
+}
 
+func (c SessionController) Refresh() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		refresh, err := requests.Bind(r, requests.RefreshRequest{}, domain.AuthTokens{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		user, authTokens, err := c.sessionServ.Refresh(refresh.RefreshToken)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidToken) {
+				Unauthorized(w, err)
+				return
+			}
 			InternalServerError(w, err)
 			return
 		}
+
 		var sessDto resources.SessionDto
-		Success(w, sessDto.DomainToDto(token, user))
+		Success(w, sessDto.DomainToDto(authTokens, user))
+	}
+}
+
+func (c SessionController) VerifyTwoFactor() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		verification, err := requests.Bind(r, requests.VerifyTwoFactorRequest{}, domain.TwoFactorVerification{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		user, authTokens, err := c.sessionServ.VerifyTwoFactor(verification, ClientInfoFromRequest(r))
+		if err != nil {
+			var throttled app.LoginThrottledError
+			if errors.As(err, &throttled) {
+				TooManyRequests(w, err, throttled.RetryAfter)
+				return
+			}
+			if errors.Is(err, app.ErrInvalidToken) || errors.Is(err, app.ErrInvalidTwoFactorCode) {
+				Unauthorized(w, err)
+				return
+			}
+			if errors.Is(err, app.ErrUserBanned) {
+				Forbidden(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		var sessDto resources.SessionDto
+		Success(w, sessDto.DomainToDto(authTokens, user))
 	}
 }
 
@@ -70,3 +137,194 @@ func (c SessionController) Logout() http.HandlerFunc {
 		Ok(w)
 	}
 }
+
+func (c SessionController) ForgotPassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, err := requests.Bind(r, requests.ForgotPasswordRequest{}, domain.User{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		err = c.sessionServ.ForgotPassword(user.Email)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) ResetPassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reset, err := requests.Bind(r, requests.ResetPasswordRequest{}, domain.PasswordReset{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		err = c.sessionServ.ResetPassword(reset)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidToken) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) VerifyEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		verification, err := requests.Bind(r, requests.VerifyEmailRequest{}, domain.EmailVerification{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		err = c.sessionServ.VerifyEmail(verification.Token)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidToken) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) ResendVerificationEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		if user.Verified {
+			BadRequest(w, errors.New("email is already verified"))
+			return
+		}
+
+		err := c.sessionServ.SendVerificationEmail(user)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) ChangePassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sess := r.Context().Value(SessionKey).(domain.Session)
+		change, err := requests.Bind(r, requests.ChangePasswordRequest{}, domain.PasswordChange{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		err = c.sessionServ.ChangePassword(sess, change)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidCredentials) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) ChangeEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		change, err := requests.Bind(r, requests.ChangeEmailRequest{}, domain.EmailChange{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		err = c.sessionServ.RequestEmailChange(user, change)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidCredentials) || errors.Is(err, app.ErrEmailTaken) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) ConfirmEmailChange() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		verification, err := requests.Bind(r, requests.VerifyEmailRequest{}, domain.EmailVerification{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		err = c.sessionServ.ConfirmEmailChange(verification.Token)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidToken) || errors.Is(err, app.ErrEmailTaken) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) FindMySessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		current := r.Context().Value(SessionKey).(domain.Session)
+
+		sessions, err := c.sessionServ.FindSessions(current.UserId)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.SessionInfoDto{}.DomainToDtoCollection(sessions, current))
+	}
+}
+
+func (c SessionController) RevokeSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		sessionUuid, err := uuid.Parse(chi.URLParam(r, "sessionId"))
+		if err != nil {
+			BadRequest(w, errors.New("invalid sessionId"))
+			return
+		}
+
+		err = c.sessionServ.RevokeSession(domain.Session{UserId: user.Id, UUID: sessionUuid})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				NotFound(w, errors.New("session not found"))
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
+
+func (c SessionController) RevokeOtherSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		current := r.Context().Value(SessionKey).(domain.Session)
+
+		err := c.sessionServ.RevokeOtherSessions(current)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+		Ok(w)
+	}
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type TicketTierController struct {
+	tierService app.TicketTierService
+}
+
+func NewTicketTierController(tierService app.TicketTierService) TicketTierController {
+	return TicketTierController{
+		tierService: tierService,
+	}
+}
+
+func (c TicketTierController) Save() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		tier, err := requests.Bind(r, requests.CreateTicketTierRequest{}, domain.TicketTier{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		tier.PartyId = party.Id
+
+		tier, err = c.tierService.Save(tier)
+		if err != nil {
+			c.handleTierError(w, err)
+			return
+		}
+
+		Created(w, resources.TicketTierDto{}.DomainToDto(tier))
+	}
+}
+
+func (c TicketTierController) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+		tierId, err := strconv.ParseUint(chi.URLParam(r, "tierId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid tierId"))
+			return
+		}
+
+		tier, err := requests.Bind(r, requests.CreateTicketTierRequest{}, domain.TicketTier{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		tier.Id = tierId
+		tier.PartyId = party.Id
+
+		tier, err = c.tierService.Update(tier)
+		if err != nil {
+			c.handleTierError(w, err)
+			return
+		}
+
+		Success(w, resources.TicketTierDto{}.DomainToDto(tier))
+	}
+}
+
+func (c TicketTierController) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+		tierId, err := strconv.ParseUint(chi.URLParam(r, "tierId"), 10, 64)
+		if err != nil {
+			BadRequest(w, errors.New("invalid tierId"))
+			return
+		}
+
+		err = c.tierService.Delete(party.Id, tierId)
+		if err != nil {
+			c.handleTierError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
+
+func (c TicketTierController) handleTierError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrTierNotFound):
+		NotFound(w, err)
+	case errors.Is(err, app.ErrTierCapacityBelow),
+		errors.Is(err, app.ErrTierHasMembers),
+		errors.Is(err, repositories.ErrTierExists):
+		BadRequest(w, err)
+	default:
+		InternalServerError(w, err)
+	}
+}
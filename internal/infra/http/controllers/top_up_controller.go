@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"go-rest-api/internal/infra/payments"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	PaymentSignatureHeader = "X-Payment-Signature"
+
+	maxPaymentWebhookSize = 1 << 20
+)
+
+type TopUpController struct {
+	topUpService app.TopUpService
+	fakeProvider *payments.FakeProvider
+}
+
+func NewTopUpController(topUpService app.TopUpService, provider payments.Provider) TopUpController {
+	fakeProvider, _ := provider.(*payments.FakeProvider)
+	return TopUpController{
+		topUpService: topUpService,
+		fakeProvider: fakeProvider,
+	}
+}
+
+func (c TopUpController) HasFakeCheckout() bool {
+	return c.fakeProvider != nil
+}
+
+func (c TopUpController) Save() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		topUp, err := requests.Bind(r, requests.CreateTopUpRequest{}, domain.TopUp{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		topUp.UserId = user.Id
+
+		topUp, err = c.topUpService.Save(topUp)
+		if err != nil {
+			if errors.Is(err, app.ErrPaymentsUnavailable) {
+				ServiceUnavailable(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Created(w, resources.TopUpDto{}.DomainToDto(topUp))
+	}
+}
+
+func (c TopUpController) FindById() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		topUp := GetPathValueFromCtx[domain.TopUp](r.Context())
+		Success(w, resources.TopUpDto{}.DomainToDto(topUp))
+	}
+}
+
+func (c TopUpController) Webhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxPaymentWebhookSize))
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+
+		err = c.topUpService.HandleWebhook(payload, r.Header.Get(PaymentSignatureHeader))
+		if err != nil {
+			switch {
+			case errors.Is(err, payments.ErrInvalidSignature):
+				Unauthorized(w, err)
+			case errors.Is(err, app.ErrPaymentsUnavailable):
+				ServiceUnavailable(w, err)
+			default:
+				InternalServerError(w, err)
+			}
+			return
+		}
+
+		Ok(w)
+	}
+}
+
+func (c TopUpController) FakeCheckout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		paid := r.URL.Query().Get("status") != domain.TopUpFailed
+		payload, signature, err := c.fakeProvider.Simulate(chi.URLParam(r, "sessionId"), paid)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		err = c.topUpService.HandleWebhook(payload, signature)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
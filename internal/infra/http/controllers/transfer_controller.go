@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/database/repositories"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+)
+
+type TransferController struct {
+	transferService app.TransferService
+}
+
+func NewTransferController(transferService app.TransferService) TransferController {
+	return TransferController{
+		transferService: transferService,
+	}
+}
+
+func (c TransferController) Save() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		transfer, err := requests.Bind(r, requests.CreateTransferRequest{}, domain.Transfer{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		transfer.SenderId = user.Id
+
+		transfer, err = c.transferService.Save(transfer)
+		if err != nil {
+			c.handleTransferError(w, err)
+			return
+		}
+
+		Created(w, resources.TransferDto{}.DomainToDto(transfer))
+	}
+}
+
+func (c TransferController) Tip() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		party := GetPathValueFromCtx[domain.Party](r.Context())
+
+		transfer, err := requests.Bind(r, requests.TipRequest{}, domain.Transfer{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		transfer.SenderId = user.Id
+
+		transfer, err = c.transferService.Tip(party, transfer)
+		if err != nil {
+			c.handleTransferError(w, err)
+			return
+		}
+
+		Created(w, resources.TransferDto{}.DomainToDto(transfer))
+	}
+}
+
+func (c TransferController) handleTransferError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrRecipientNotFound):
+		NotFound(w, err)
+	case errors.Is(err, app.ErrNotPartyMember),
+		errors.Is(err, app.ErrCannotTipOwnParty),
+		errors.Is(err, app.ErrEmailNotVerified):
+		Forbidden(w, err)
+	case errors.Is(err, app.ErrTransferToSelf),
+		errors.Is(err, app.ErrZeroTransferAmount),
+		errors.Is(err, app.ErrPartyNotFinished),
+		errors.Is(err, repositories.ErrInsufficientFunds),
+		errors.Is(err, repositories.ErrDailyTransferLimitExceeded):
+		BadRequest(w, err)
+	default:
+		InternalServerError(w, err)
+	}
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+)
+
+type TwoFactorController struct {
+	twoFactorServ app.TwoFactorService
+}
+
+func NewTwoFactorController(twoFactorServ app.TwoFactorService) TwoFactorController {
+	return TwoFactorController{twoFactorServ}
+}
+
+func (c TwoFactorController) Enroll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		enrollment, err := c.twoFactorServ.Enroll(user)
+		if err != nil {
+			if errors.Is(err, app.ErrTwoFactorEnabled) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		var enrollmentDto resources.TotpEnrollmentDto
+		Success(w, enrollmentDto.DomainToDto(enrollment))
+	}
+}
+
+func (c TwoFactorController) Confirm() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		verification, err := requests.Bind(r, requests.TotpCodeRequest{}, domain.TwoFactorVerification{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		codes, err := c.twoFactorServ.Confirm(user, verification.Code)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidTwoFactorCode) || errors.Is(err, app.ErrTwoFactorEnabled) || errors.Is(err, app.ErrTwoFactorDisabled) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.RecoveryCodesDto{RecoveryCodes: codes})
+	}
+}
+
+func (c TwoFactorController) Disable() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+		verification, err := requests.Bind(r, requests.TotpCodeRequest{}, domain.TwoFactorVerification{})
+		if err != nil {
+			BadRequest(w, errors.New("invalid request body"))
+			return
+		}
+
+		err = c.twoFactorServ.Disable(user, verification.Code)
+		if err != nil {
+			if errors.Is(err, app.ErrInvalidTwoFactorCode) || errors.Is(err, app.ErrTwoFactorDisabled) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/resources"
+	"go-rest-api/internal/infra/imaging"
+	"io"
+	"net/http"
+)
+
+const multipartOverhead = 64 << 10
+
+const uploadFormField = "file"
+
+type UploadController struct {
+	uploadService app.UploadService
+	maxSize       int64
+}
+
+func NewUploadController(uploadService app.UploadService, maxSize int64) UploadController {
+	return UploadController{
+		uploadService: uploadService,
+		maxSize:       maxSize,
+	}
+}
+
+func (c UploadController) Save() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		if r.ContentLength > c.maxSize+multipartOverhead {
+			RequestEntityTooLarge(w, app.ErrUploadTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, c.maxSize+multipartOverhead)
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			BadRequest(w, errors.New("expected a multipart/form-data request"))
+			return
+		}
+
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				BadRequest(w, errors.New("the file field is required"))
+				return
+			}
+			if err != nil {
+				c.handleUploadError(w, err)
+				return
+			}
+			if part.FormName() != uploadFormField || part.FileName() == "" {
+				part.Close()
+				continue
+			}
+
+			upload, err := c.uploadService.Save(domain.Upload{UserId: user.Id}, part)
+			part.Close()
+			if err != nil {
+				c.handleUploadError(w, err)
+				return
+			}
+
+			Created(w, resources.UploadDto{}.DomainToDto(upload))
+			return
+		}
+	}
+}
+
+func (c UploadController) handleUploadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, app.ErrUploadTooLarge),
+		errors.As(err, &maxBytesErr):
+		RequestEntityTooLarge(w, app.ErrUploadTooLarge)
+	case errors.Is(err, imaging.ErrUnsupportedImage):
+		UnsupportedMediaType(w, err)
+	case errors.Is(err, imaging.ErrInvalidImage),
+		errors.Is(err, imaging.ErrImageDimensions):
+		BadRequest(w, err)
+	default:
+		InternalServerError(w, err)
+	}
+}
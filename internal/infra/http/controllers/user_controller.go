@@ -20,25 +20,6 @@ func NewUserController(userService app.UserService) UserController {
 	return UserController{userService: userService}
 }
 
-func (c UserController) UpdateMyBalance() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		user := r.Context().Value(UserKey).(domain.User)
-
-		amount, err := requests.Bind(r, requests.UpdateMyBalanceRequest{}, domain.UpdateUserBalanceAmount{})
-		if err != nil {
-			BadRequest(w, err)
-			return
-		}
-
-		updatedUser, err := c.userService.UpdateUserBalance(user, amount.Amount)
-		if err != nil {
-			BadRequest(w, err)
-			return
-		}
-		Success(w, resources.UserDto{}.DomainToDto(updatedUser))
-	}
-}
-
 func (c UserController) FindUserById() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userId := chi.URLParam(r, "userId")
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/internal/app"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/requests"
+	"go-rest-api/internal/infra/http/resources"
+	"net/http"
+	"strconv"
+)
+
+type WebhookController struct {
+	webhookService app.WebhookService
+}
+
+func NewWebhookController(webhookService app.WebhookService) WebhookController {
+	return WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+func (c WebhookController) Save() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		domainWebhook, err := requests.Bind(r, requests.CreateWebhookRequest{}, domain.Webhook{})
+		if err != nil {
+			BadRequest(w, err)
+			return
+		}
+		domainWebhook.UserId = user.Id
+
+		domainWebhook, err = c.webhookService.Save(domainWebhook)
+		if err != nil {
+			if errors.Is(err, app.ErrWebhookUrlNotAllowed) {
+				BadRequest(w, err)
+				return
+			}
+			InternalServerError(w, err)
+			return
+		}
+
+		Created(w, resources.WebhookDto{}.DomainToDtoWithSecret(domainWebhook))
+	}
+}
+
+func (c WebhookController) FindMy() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := r.Context().Value(UserKey).(domain.User)
+
+		webhooks, err := c.webhookService.FindByUserId(user.Id)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.WebhookDto{}.DomainToDtoCollection(webhooks))
+	}
+}
+
+func (c WebhookController) FindDeliveries() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhook := GetPathValueFromCtx[domain.Webhook](r.Context())
+		page := r.URL.Query().Get("page")
+		limit := r.URL.Query().Get("limit")
+		if page == "" || limit == "" {
+			BadRequest(w, errors.New("invalid page or limit"))
+			return
+		}
+		numericPage, pErr := strconv.ParseInt(page, 10, 32)
+		numericLimit, lErr := strconv.ParseInt(limit, 10, 32)
+		if pErr != nil || lErr != nil {
+			BadRequest(w, errors.New("invalid page or limit"))
+			return
+		}
+
+		deliveries, err := c.webhookService.FindDeliveries(webhook.Id, int32(numericPage), int32(numericLimit))
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Success(w, resources.WebhookDeliveryDto{}.DomainToDtoCollection(deliveries))
+	}
+}
+
+func (c WebhookController) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhook := GetPathValueFromCtx[domain.Webhook](r.Context())
+
+		err := c.webhookService.Delete(webhook.Id)
+		if err != nil {
+			InternalServerError(w, err)
+			return
+		}
+
+		Ok(w)
+	}
+}
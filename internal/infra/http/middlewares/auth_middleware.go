@@ -10,15 +10,34 @@ import (
 	"go-rest-api/internal/app"
 	"go-rest-api/internal/domain"
 	"go-rest-api/internal/infra/http/controllers"
+	"go-rest-api/internal/infra/tokens"
 	"net/http"
 )
 
-func AuthMiddleware(ja *jwtauth.JWTAuth, sessionServ app.SessionService, userServ app.UserService) func(next http.Handler) http.Handler {
+func AuthMiddleware(ja *tokens.JWTAuth, sessionServ app.SessionService, userServ app.UserService, patServ app.PersonalAccessTokenService) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		hfn := func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			token, err := jwtauth.VerifyRequest(ja, r, jwtauth.TokenFromHeader)
+			bearer := jwtauth.TokenFromHeader(r)
+			if app.IsPersonalAccessToken(bearer) {
+				pat, err := patServ.Authenticate(bearer)
+				if err != nil {
+					controllers.Unauthorized(w, err)
+					return
+				}
+				user, ok := findActiveUser(w, userServ, pat.UserId)
+				if !ok {
+					return
+				}
+				ctx = context.WithValue(ctx, controllers.UserKey, user)
+				ctx = context.WithValue(ctx, controllers.TokenKey, pat)
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			token, err := ja.Decode(bearer)
 			if err != nil {
 				controllers.Unauthorized(w, err)
 				return
@@ -30,29 +49,30 @@ func AuthMiddleware(ja *jwtauth.JWTAuth, sessionServ app.SessionService, userSer
 			}
 
 			claims := token.PrivateClaims()
-			userId := uint64(claims["user_id"].(float64))
-			userUuid, err := uuid.Parse(claims["uuid"].(string))
+			userIdClaim, idOk := claims["user_id"].(float64)
+			uuidClaim, uuidOk := claims["uuid"].(string)
+			if !idOk || !uuidOk {
+				controllers.Unauthorized(w, errors.New("token is unauthorized"))
+				return
+			}
+			userId := uint64(userIdClaim)
+			userUuid, err := uuid.Parse(uuidClaim)
 			if err != nil {
 				controllers.Unauthorized(w, err)
 				return
 			}
 
-			sess := domain.Session{
+			sess, err := sessionServ.Check(domain.Session{
 				UserId: userId,
 				UUID:   userUuid,
-			}
-			err = sessionServ.Check(sess)
+			}, controllers.ClientInfoFromRequest(r))
 			if err != nil {
 				controllers.Unauthorized(w, err)
 				return
 			}
 
-			user, err := userServ.FindById(sess.UserId)
-			if err != nil {
-				if errors.Is(err, sql.ErrNoRows) {
-					err = errors.New("token is unauthorized")
-				}
-				controllers.Unauthorized(w, err)
+			user, ok := findActiveUser(w, userServ, sess.UserId)
+			if !ok {
 				return
 			}
 			ctx = context.WithValue(ctx, controllers.UserKey, user)
@@ -63,3 +83,19 @@ func AuthMiddleware(ja *jwtauth.JWTAuth, sessionServ app.SessionService, userSer
 		return http.HandlerFunc(hfn)
 	}
 }
+
+func findActiveUser(w http.ResponseWriter, userServ app.UserService, userId uint64) (domain.User, bool) {
+	user, err := userServ.FindById(userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = errors.New("token is unauthorized")
+		}
+		controllers.Unauthorized(w, err)
+		return domain.User{}, false
+	}
+	if user.IsBanned() {
+		controllers.Forbidden(w, app.ErrUserBanned)
+		return domain.User{}, false
+	}
+	return user, true
+}
@@ -0,0 +1,72 @@
+package middlewares
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"strings"
+)
+
+func RealIpMiddleware(trustedProxies []string) func(http.Handler) http.Handler {
+	var trusted []*net.IPNet
+	for _, proxy := range trustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			log.Printf("RealIpMiddleware: invalid trusted proxy %q", proxy)
+			continue
+		}
+		trusted = append(trusted, network)
+	}
+
+	isTrusted := func(ip net.IP) bool {
+		for _, network := range trusted {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		hfn := func(w http.ResponseWriter, r *http.Request) {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				host = r.RemoteAddr
+			}
+			peer := net.ParseIP(host)
+			if peer == nil || !isTrusted(peer) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The rightmost address that isn't a proxy is the client, the rest can be forged.
+			var client net.IP
+			forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+			for i := len(forwarded) - 1; i >= 0; i-- {
+				ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+				if ip == nil {
+					break
+				}
+				client = ip
+				if !isTrusted(ip) {
+					break
+				}
+			}
+			if client == nil {
+				client = net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP")))
+			}
+			if client != nil {
+				r.RemoteAddr = client.String()
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(hfn)
+	}
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"errors"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/controllers"
+	"net/http"
+)
+
+func RequireRole(role domain.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		hfn := func(w http.ResponseWriter, r *http.Request) {
+			user := r.Context().Value(controllers.UserKey).(domain.User)
+
+			if !user.HasRole(role) {
+				err := errors.New("you have no access to this resource")
+				controllers.Forbidden(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(hfn)
+	}
+}
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/http/controllers"
+	"net/http"
+)
+
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		hfn := func(w http.ResponseWriter, r *http.Request) {
+			token, ok := r.Context().Value(controllers.TokenKey).(domain.PersonalAccessToken)
+			if ok && !token.HasScope(scope) {
+				err := fmt.Errorf("token is missing the %s scope", scope)
+				controllers.Forbidden(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(hfn)
+	}
+}
+
+func RequireSession(next http.Handler) http.Handler {
+	hfn := func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Context().Value(controllers.SessionKey).(domain.Session)
+		if !ok {
+			controllers.Forbidden(w, errors.New("personal access tokens cannot be used here"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(hfn)
+}
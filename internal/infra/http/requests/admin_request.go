@@ -0,0 +1,35 @@
+package requests
+
+import "go-rest-api/internal/domain"
+
+type BanUserRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user moderator admin"`
+}
+
+type AdjustBalanceRequest struct {
+	Amount int32  `json:"amount" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func (r BanUserRequest) ToDomainModel() (interface{}, error) {
+	return domain.UserBan{
+		Reason: r.Reason,
+	}, nil
+}
+
+func (r ChangeRoleRequest) ToDomainModel() (interface{}, error) {
+	return domain.RoleChange{
+		Role: domain.Role(r.Role),
+	}, nil
+}
+
+func (r AdjustBalanceRequest) ToDomainModel() (interface{}, error) {
+	return domain.BalanceAdjustment{
+		Amount: r.Amount,
+		Reason: r.Reason,
+	}, nil
+}
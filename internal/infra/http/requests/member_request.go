@@ -0,0 +1,15 @@
+package requests
+
+import "go-rest-api/internal/domain"
+
+type JoinPartyRequest struct {
+	TierId    *uint64 `json:"tierId" validate:"omitempty,min=1"`
+	PromoCode string  `json:"promoCode" validate:"max=64"`
+}
+
+func (r JoinPartyRequest) ToDomainModel() (interface{}, error) {
+	return domain.PartyJoin{
+		TierId:    r.TierId,
+		PromoCode: r.PromoCode,
+	}, nil
+}
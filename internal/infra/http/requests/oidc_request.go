@@ -0,0 +1,13 @@
+package requests
+
+import "go-rest-api/internal/domain"
+
+type OidcExchangeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+func (r OidcExchangeRequest) ToDomainModel() (interface{}, error) {
+	return domain.OidcExchange{
+		Code: r.Code,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package requests
+
+import (
+	"go-rest-api/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type CreatePartyImageRequest struct {
+	UploadId uuid.UUID `json:"uploadId" validate:"required"`
+}
+
+func (r CreatePartyImageRequest) ToDomainModel() (interface{}, error) {
+	return domain.PartyImage{
+		UploadId: r.UploadId,
+	}, nil
+}
+
+type ReorderPartyImagesRequest struct {
+	ImageIds []uint64 `json:"imageIds" validate:"required,min=1,max=10,dive,required"`
+}
+
+func (r ReorderPartyImagesRequest) ToDomainModel() (interface{}, error) {
+	return r.ImageIds, nil
+}
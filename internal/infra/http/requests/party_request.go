@@ -3,38 +3,67 @@ package requests
 import (
 	"go-rest-api/internal/domain"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type CreatePartyRequest struct {
-	Title       string    `json:"title" validate:"required"`
-	Description string    `json:"description" validate:"required"`
-	Image       string    `json:"image"`
-	Price       int32     `json:"price" validate:"required"`
-	StartDate   time.Time `json:"startDate" validate:"required"`
+	Title         string                    `json:"title" validate:"required"`
+	Description   string                    `json:"description" validate:"required"`
+	ImageUploadId *uuid.UUID                `json:"imageUploadId"`
+	Price         int32                     `json:"price" validate:"required_without=Tiers"`
+	StartDate     time.Time                 `json:"startDate" validate:"required"`
+	EndDate       time.Time                 `json:"endDate" validate:"required"`
+	Timezone      string                    `json:"timezone" validate:"omitempty,timezone"`
+	Tiers         []CreateTicketTierRequest `json:"tiers" validate:"omitempty,max=10,dive"`
+	Recurrence    string                    `json:"recurrence" validate:"max=200"`
 }
 
 func (cpr CreatePartyRequest) ToDomainModel() (interface{}, error) {
-	return domain.Party{
+	tiers := make([]domain.TicketTier, len(cpr.Tiers))
+	for i, tier := range cpr.Tiers {
+		tiers[i] = domain.TicketTier{
+			Name:     tier.Name,
+			Price:    tier.Price,
+			Capacity: tier.Capacity,
+		}
+	}
+
+	party := domain.Party{
 		Title:       cpr.Title,
 		Description: cpr.Description,
-		Image:       cpr.Image,
 		Price:       cpr.Price,
 		StartDate:   cpr.StartDate,
-	}, nil
+		EndDate:     cpr.EndDate,
+		Timezone:    cpr.Timezone,
+		Tiers:       tiers,
+		Recurrence:  cpr.Recurrence,
+	}
+	if cpr.ImageUploadId != nil {
+		party.ImageUploadId = *cpr.ImageUploadId
+	}
+	return party, nil
 }
 
 type UpdatePartyRequest struct {
-	Title       string    `json:"title" validate:"required"`
-	Description string    `json:"description" validate:"required"`
-	Image       string    `json:"image"`
-	StartDate   time.Time `json:"startDate" validate:"required"`
+	Title         string     `json:"title" validate:"required"`
+	Description   string     `json:"description" validate:"required"`
+	ImageUploadId *uuid.UUID `json:"imageUploadId"`
+	StartDate     time.Time  `json:"startDate" validate:"required"`
+	EndDate       time.Time  `json:"endDate" validate:"required"`
+	Timezone      string     `json:"timezone" validate:"omitempty,timezone"`
 }
 
 func (upr UpdatePartyRequest) ToDomainModel() (interface{}, error) {
-	return domain.Party{
+	party := domain.Party{
 		Title:       upr.Title,
 		Description: upr.Description,
-		Image:       upr.Image,
 		StartDate:   upr.StartDate,
-	}, nil
+		EndDate:     upr.EndDate,
+		Timezone:    upr.Timezone,
+	}
+	if upr.ImageUploadId != nil {
+		party.ImageUploadId = *upr.ImageUploadId
+	}
+	return party, nil
 }
@@ -0,0 +1,24 @@
+package requests
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type CreatePersonalAccessTokenRequest struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,unique,dive,oneof=users:read users:write parties:read parties:write webhooks:read webhooks:write"`
+	ExpiresInDays int32    `json:"expiresInDays" validate:"omitempty,min=1,max=3650"`
+}
+
+func (r CreatePersonalAccessTokenRequest) ToDomainModel() (interface{}, error) {
+	token := domain.PersonalAccessToken{
+		Name:   r.Name,
+		Scopes: r.Scopes,
+	}
+	if r.ExpiresInDays > 0 {
+		expiresDate := time.Now().AddDate(0, 0, int(r.ExpiresInDays))
+		token.ExpiresDate = &expiresDate
+	}
+	return token, nil
+}
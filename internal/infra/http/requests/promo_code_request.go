@@ -0,0 +1,30 @@
+package requests
+
+import (
+	"errors"
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type CreatePromoCodeRequest struct {
+	Code           string     `json:"code" validate:"required,alphanum,min=3,max=32"`
+	DiscountType   string     `json:"discountType" validate:"required,oneof=percent fixed"`
+	DiscountValue  int32      `json:"discountValue" validate:"required,min=1"`
+	MaxRedemptions *int32     `json:"maxRedemptions" validate:"omitempty,min=1"`
+	ValidFrom      *time.Time `json:"validFrom"`
+	ValidUntil     *time.Time `json:"validUntil"`
+}
+
+func (r CreatePromoCodeRequest) ToDomainModel() (interface{}, error) {
+	if r.DiscountType == domain.DiscountPercent && r.DiscountValue > 100 {
+		return nil, errors.New("discountValue must be at most 100 for percent discounts")
+	}
+	return domain.PromoCode{
+		Code:           r.Code,
+		DiscountType:   r.DiscountType,
+		DiscountValue:  r.DiscountValue,
+		MaxRedemptions: r.MaxRedemptions,
+		ValidFrom:      r.ValidFrom,
+		ValidUntil:     r.ValidUntil,
+	}, nil
+}
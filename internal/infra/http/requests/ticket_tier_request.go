@@ -0,0 +1,17 @@
+package requests
+
+import "go-rest-api/internal/domain"
+
+type CreateTicketTierRequest struct {
+	Name     string `json:"name" validate:"required,max=50"`
+	Price    int32  `json:"price" validate:"min=0"`
+	Capacity int32  `json:"capacity" validate:"required,min=1"`
+}
+
+func (r CreateTicketTierRequest) ToDomainModel() (interface{}, error) {
+	return domain.TicketTier{
+		Name:     r.Name,
+		Price:    r.Price,
+		Capacity: r.Capacity,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package requests
+
+import "go-rest-api/internal/domain"
+
+type CreateTopUpRequest struct {
+	Amount int32 `json:"amount" validate:"required,min=1,max=100000"`
+}
+
+func (r CreateTopUpRequest) ToDomainModel() (interface{}, error) {
+	return domain.TopUp{
+		Amount: r.Amount,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package requests
+
+import "go-rest-api/internal/domain"
+
+type CreateTransferRequest struct {
+	RecipientId uint64 `json:"recipientId" validate:"required"`
+	Amount      int32  `json:"amount" validate:"required,min=1"`
+	Note        string `json:"note" validate:"max=255"`
+}
+
+type TipRequest struct {
+	Amount int32  `json:"amount" validate:"required,min=1"`
+	Note   string `json:"note" validate:"max=255"`
+}
+
+func (r CreateTransferRequest) ToDomainModel() (interface{}, error) {
+	return domain.Transfer{
+		RecipientId: r.RecipientId,
+		Amount:      r.Amount,
+		Note:        r.Note,
+	}, nil
+}
+
+func (r TipRequest) ToDomainModel() (interface{}, error) {
+	return domain.Transfer{
+		Amount: r.Amount,
+		Note:   r.Note,
+	}, nil
+}
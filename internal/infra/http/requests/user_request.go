@@ -6,7 +6,7 @@ import (
 
 type RegisterRequest struct {
 	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
 
@@ -15,8 +15,40 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
-type UpdateMyBalanceRequest struct {
-	Amount int32 `json:"amount" validate:"required"`
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	NewPassword     string `json:"newPassword" validate:"required,min=8,nefield=CurrentPassword"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type TotpCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required"`
 }
 
 func (r RegisterRequest) ToDomainModel() (interface{}, error) {
@@ -34,8 +66,54 @@ func (r LoginRequest) ToDomainModel() (interface{}, error) {
 	}, nil
 }
 
-func (r UpdateMyBalanceRequest) ToDomainModel() (interface{}, error) {
-	return domain.UpdateUserBalanceAmount{
-		Amount: r.Amount,
+func (r RefreshRequest) ToDomainModel() (interface{}, error) {
+	return domain.AuthTokens{
+		RefreshToken: r.RefreshToken,
+	}, nil
+}
+
+func (r ForgotPasswordRequest) ToDomainModel() (interface{}, error) {
+	return domain.User{
+		Email: r.Email,
+	}, nil
+}
+
+func (r ResetPasswordRequest) ToDomainModel() (interface{}, error) {
+	return domain.PasswordReset{
+		Token:    r.Token,
+		Password: r.Password,
+	}, nil
+}
+
+func (r VerifyEmailRequest) ToDomainModel() (interface{}, error) {
+	return domain.EmailVerification{
+		Token: r.Token,
+	}, nil
+}
+
+func (r ChangePasswordRequest) ToDomainModel() (interface{}, error) {
+	return domain.PasswordChange{
+		CurrentPassword: r.CurrentPassword,
+		NewPassword:     r.NewPassword,
+	}, nil
+}
+
+func (r ChangeEmailRequest) ToDomainModel() (interface{}, error) {
+	return domain.EmailChange{
+		Email:    r.Email,
+		Password: r.Password,
+	}, nil
+}
+
+func (r TotpCodeRequest) ToDomainModel() (interface{}, error) {
+	return domain.TwoFactorVerification{
+		Code: r.Code,
+	}, nil
+}
+
+func (r VerifyTwoFactorRequest) ToDomainModel() (interface{}, error) {
+	return domain.TwoFactorVerification{
+		ChallengeToken: r.ChallengeToken,
+		Code:           r.Code,
 	}, nil
 }
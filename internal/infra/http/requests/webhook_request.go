@@ -0,0 +1,24 @@
+package requests
+
+import (
+	"fmt"
+	"go-rest-api/internal/domain"
+	"slices"
+)
+
+type CreateWebhookRequest struct {
+	Url    string   `json:"url" validate:"required,https_url"`
+	Events []string `json:"events" validate:"required,min=1,unique"`
+}
+
+func (r CreateWebhookRequest) ToDomainModel() (interface{}, error) {
+	for _, event := range r.Events {
+		if !slices.Contains(domain.WebhookEventTypes, event) {
+			return nil, fmt.Errorf("unknown webhook event %q", event)
+		}
+	}
+	return domain.Webhook{
+		Url:    r.Url,
+		Events: r.Events,
+	}, nil
+}
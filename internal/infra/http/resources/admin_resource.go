@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type AdminUserDto struct {
+	UserDto
+	TotpEnabled bool       `json:"totpEnabled"`
+	BannedDate  *time.Time `json:"bannedDate"`
+	BanReason   string     `json:"banReason,omitempty"`
+}
+
+type AdminUsersDto struct {
+	Users       []AdminUserDto `json:"items"`
+	Total       uint64         `json:"total"`
+	CurrentPage int32          `json:"currentPage"`
+	LastPage    int32          `json:"lastPage"`
+}
+
+func (u AdminUserDto) DomainToDto(user domain.User) AdminUserDto {
+	var userDto UserDto
+	return AdminUserDto{
+		UserDto:     userDto.DomainToDto(user),
+		TotpEnabled: user.TotpEnabled,
+		BannedDate:  user.BannedDate,
+		BanReason:   user.BanReason,
+	}
+}
+
+func (u AdminUserDto) DomainToDtoCollection(domainUsers domain.Users) AdminUsersDto {
+	result := make([]AdminUserDto, len(domainUsers.Users))
+
+	for i := range domainUsers.Users {
+		result[i] = u.DomainToDto(domainUsers.Users[i])
+	}
+
+	return AdminUsersDto{
+		Users:       result,
+		Total:       domainUsers.Total,
+		CurrentPage: domainUsers.CurrentPage,
+		LastPage:    domainUsers.LastPage,
+	}
+}
+
+type PointTransactionDto struct {
+	Id          uint64    `json:"id"`
+	UserId      uint64    `json:"userId"`
+	Amount      int32     `json:"amount"`
+	Balance     int32     `json:"balance"`
+	Type        string    `json:"type"`
+	Reason      string    `json:"reason"`
+	ActorId     *uint64   `json:"actorId"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+func (t PointTransactionDto) DomainToDto(transaction domain.PointTransaction) PointTransactionDto {
+	return PointTransactionDto{
+		Id:          transaction.Id,
+		UserId:      transaction.UserId,
+		Amount:      transaction.Amount,
+		Balance:     transaction.Balance,
+		Type:        transaction.Type,
+		Reason:      transaction.Reason,
+		ActorId:     transaction.ActorId,
+		CreatedDate: transaction.CreatedDate,
+	}
+}
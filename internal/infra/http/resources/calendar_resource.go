@@ -0,0 +1,18 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type CalendarFeedDto struct {
+	Url         string    `json:"url"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+func (c CalendarFeedDto) DomainToDto(feed domain.CalendarFeed) CalendarFeedDto {
+	return CalendarFeedDto{
+		Url:         feed.Url,
+		CreatedDate: feed.CreatedDate,
+	}
+}
@@ -26,6 +26,31 @@ func (m MemberDto) DomainToDtoCollection(domainUsers []domain.User) []MemberDto
 	return result
 }
 
+type PartyMemberDto struct {
+	MemberDto
+	Tier *TicketTierDto `json:"tier"`
+}
+
+func (m PartyMemberDto) DomainToDtoCollection(members []domain.PartyMember, tiers []domain.TicketTier) []PartyMemberDto {
+	result := make([]PartyMemberDto, len(members))
+
+	for i, member := range members {
+		result[i] = PartyMemberDto{MemberDto: MemberDto{}.DomainToDto(member.User)}
+		if member.TierId == nil {
+			continue
+		}
+		for _, tier := range tiers {
+			if tier.Id == *member.TierId {
+				tierDto := TicketTierDto{}.DomainToDto(tier)
+				result[i].Tier = &tierDto
+				break
+			}
+		}
+	}
+
+	return result
+}
+
 type MemberExistsDto struct {
 	IsJoined bool `json:"isJoined"`
 }
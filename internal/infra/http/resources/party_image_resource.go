@@ -0,0 +1,46 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type PartyImageDto struct {
+	Id           uint64    `json:"id"`
+	UserId       uint64    `json:"userId"`
+	Url          string    `json:"url"`
+	MediumUrl    string    `json:"mediumUrl"`
+	ThumbnailUrl string    `json:"thumbnailUrl"`
+	Status       string    `json:"status"`
+	Position     int32     `json:"position"`
+	IsCover      bool      `json:"isCover"`
+	CreatedDate  time.Time `json:"createdDate"`
+}
+
+type PartyImagesDto struct {
+	Images []PartyImageDto `json:"images"`
+}
+
+func (i PartyImageDto) DomainToDto(image domain.PartyImage) PartyImageDto {
+	return PartyImageDto{
+		Id:           image.Id,
+		UserId:       image.UserId,
+		Url:          image.Url,
+		MediumUrl:    image.MediumUrl,
+		ThumbnailUrl: image.ThumbnailUrl,
+		Status:       image.Status,
+		Position:     image.Position,
+		IsCover:      image.IsCover,
+		CreatedDate:  image.CreatedDate,
+	}
+}
+
+func (i PartyImageDto) DomainToDtoCollection(images []domain.PartyImage) PartyImagesDto {
+	result := make([]PartyImageDto, len(images))
+
+	for j := range images {
+		result[j] = i.DomainToDto(images[j])
+	}
+
+	return PartyImagesDto{Images: result}
+}
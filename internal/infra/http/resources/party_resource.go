@@ -6,24 +6,36 @@ import (
 )
 
 type PartyDto struct {
-	Id          uint64    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Image       string    `json:"image"`
-	Price       int32     `json:"price"`
-	StartDate   time.Time `json:"startDate"`
-	CreatorId   MemberDto `json:"creatorId"`
+	Id             uint64    `json:"id"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	Image          string    `json:"image"`
+	ImageMedium    string    `json:"imageMedium"`
+	ImageThumbnail string    `json:"imageThumbnail"`
+	Price          int32     `json:"price"`
+	StartDate      time.Time `json:"startDate"`
+	EndDate        time.Time `json:"endDate"`
+	Timezone       string    `json:"timezone"`
+	CreatorId      MemberDto `json:"creatorId"`
+	TipsTotal      int32     `json:"tipsTotal"`
+	SeriesId       *uint64   `json:"seriesId"`
 }
 
 func (p PartyDto) DomainToDto(domainParty domain.Party, userDto MemberDto) PartyDto {
 	return PartyDto{
-		Id:          domainParty.Id,
-		Title:       domainParty.Title,
-		Description: domainParty.Description,
-		Image:       domainParty.Image,
-		Price:       domainParty.Price,
-		StartDate:   domainParty.StartDate,
-		CreatorId:   userDto,
+		Id:             domainParty.Id,
+		Title:          domainParty.Title,
+		Description:    domainParty.Description,
+		Image:          domainParty.Image,
+		ImageMedium:    domainParty.ImageMedium,
+		ImageThumbnail: domainParty.ImageThumbnail,
+		Price:          domainParty.Price,
+		StartDate:      domainParty.StartDate.In(domainParty.Location()),
+		EndDate:        domainParty.EndDate.In(domainParty.Location()),
+		Timezone:       domainParty.Timezone,
+		CreatorId:      userDto,
+		TipsTotal:      domainParty.TipsTotal,
+		SeriesId:       domainParty.SeriesId,
 	}
 }
 
@@ -50,25 +62,63 @@ func (p PartyDto) DomainToDtoCollection(domainParties domain.Parties, usersDto [
 }
 
 type PartyWithMembersDto struct {
-	Id          uint64      `json:"id"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	Image       string      `json:"image"`
-	Price       int32       `json:"price"`
-	StartDate   time.Time   `json:"startDate"`
-	CreatorId   MemberDto   `json:"creatorId"`
-	Members     []MemberDto `json:"members"`
+	Id             uint64           `json:"id"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	Image          string           `json:"image"`
+	ImageMedium    string           `json:"imageMedium"`
+	ImageThumbnail string           `json:"imageThumbnail"`
+	Price          int32            `json:"price"`
+	StartDate      time.Time        `json:"startDate"`
+	EndDate        time.Time        `json:"endDate"`
+	Timezone       string           `json:"timezone"`
+	CreatorId      MemberDto        `json:"creatorId"`
+	TipsTotal      int32            `json:"tipsTotal"`
+	SeriesId       *uint64          `json:"seriesId"`
+	Tiers          []TicketTierDto  `json:"tiers"`
+	Images         []PartyImageDto  `json:"images"`
+	Members        []PartyMemberDto `json:"members"`
 }
 
-func (p PartyWithMembersDto) DomainPartyWithMembersToDto(domainParty domain.Party, memberDto MemberDto, members []MemberDto) PartyWithMembersDto {
+func (p PartyWithMembersDto) DomainPartyWithMembersToDto(domainParty domain.Party, memberDto MemberDto, members []PartyMemberDto) PartyWithMembersDto {
 	return PartyWithMembersDto{
-		Id:          domainParty.Id,
-		Title:       domainParty.Title,
-		Description: domainParty.Description,
-		Image:       domainParty.Image,
-		Price:       domainParty.Price,
-		StartDate:   domainParty.StartDate,
-		CreatorId:   memberDto,
-		Members:     members,
+		Id:             domainParty.Id,
+		Title:          domainParty.Title,
+		Description:    domainParty.Description,
+		Image:          domainParty.Image,
+		ImageMedium:    domainParty.ImageMedium,
+		ImageThumbnail: domainParty.ImageThumbnail,
+		Price:          domainParty.Price,
+		StartDate:      domainParty.StartDate.In(domainParty.Location()),
+		EndDate:        domainParty.EndDate.In(domainParty.Location()),
+		Timezone:       domainParty.Timezone,
+		CreatorId:      memberDto,
+		TipsTotal:      domainParty.TipsTotal,
+		SeriesId:       domainParty.SeriesId,
+		Tiers:          TicketTierDto{}.DomainToDtoCollection(domainParty.Tiers),
+		Images:         PartyImageDto{}.DomainToDtoCollection(domainParty.Images).Images,
+		Members:        members,
+	}
+}
+
+type PartySeriesDto struct {
+	Id          uint64     `json:"id"`
+	Rule        string     `json:"rule"`
+	CreatedDate time.Time  `json:"createdDate"`
+	Parties     []PartyDto `json:"parties"`
+}
+
+func (s PartySeriesDto) DomainToDto(series domain.PartySeries, creatorDto MemberDto) PartySeriesDto {
+	parties := make([]PartyDto, len(series.Parties))
+
+	for i := range series.Parties {
+		parties[i] = PartyDto{}.DomainToDto(series.Parties[i], creatorDto)
+	}
+
+	return PartySeriesDto{
+		Id:          series.Id,
+		Rule:        series.Rule,
+		CreatedDate: series.CreatedDate,
+		Parties:     parties,
 	}
 }
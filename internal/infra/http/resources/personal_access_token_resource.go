@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type PersonalAccessTokenDto struct {
+	Id           uint64     `json:"id"`
+	Name         string     `json:"name"`
+	Prefix       string     `json:"prefix"`
+	Scopes       []string   `json:"scopes"`
+	Token        string     `json:"token,omitempty"`
+	ExpiresDate  *time.Time `json:"expiresDate"`
+	LastUsedDate *time.Time `json:"lastUsedDate"`
+	CreatedDate  time.Time  `json:"createdDate"`
+}
+
+type PersonalAccessTokensDto struct {
+	Tokens []PersonalAccessTokenDto `json:"tokens"`
+}
+
+func (t PersonalAccessTokenDto) DomainToDto(token domain.PersonalAccessToken) PersonalAccessTokenDto {
+	return PersonalAccessTokenDto{
+		Id:           token.Id,
+		Name:         token.Name,
+		Prefix:       token.Prefix,
+		Scopes:       token.Scopes,
+		ExpiresDate:  token.ExpiresDate,
+		LastUsedDate: token.LastUsedDate,
+		CreatedDate:  token.CreatedDate,
+	}
+}
+
+func (t PersonalAccessTokenDto) DomainToDtoWithToken(token domain.PersonalAccessToken) PersonalAccessTokenDto {
+	dto := t.DomainToDto(token)
+	dto.Token = token.Token
+	return dto
+}
+
+func (t PersonalAccessTokenDto) DomainToDtoCollection(tokens []domain.PersonalAccessToken) PersonalAccessTokensDto {
+	result := make([]PersonalAccessTokenDto, len(tokens))
+
+	for i := range tokens {
+		result[i] = t.DomainToDto(tokens[i])
+	}
+
+	return PersonalAccessTokensDto{Tokens: result}
+}
@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type PromoCodeDto struct {
+	Id             uint64     `json:"id"`
+	PartyId        uint64     `json:"partyId"`
+	Code           string     `json:"code"`
+	DiscountType   string     `json:"discountType"`
+	DiscountValue  int32      `json:"discountValue"`
+	MaxRedemptions *int32     `json:"maxRedemptions"`
+	Redemptions    int32      `json:"redemptions"`
+	ValidFrom      *time.Time `json:"validFrom"`
+	ValidUntil     *time.Time `json:"validUntil"`
+	CreatedDate    time.Time  `json:"createdDate"`
+}
+
+type PromoRedemptionDto struct {
+	Id          uint64    `json:"id"`
+	UserId      uint64    `json:"userId"`
+	Price       int32     `json:"price"`
+	Discount    int32     `json:"discount"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+func (p PromoCodeDto) DomainToDto(promoCode domain.PromoCode) PromoCodeDto {
+	return PromoCodeDto{
+		Id:             promoCode.Id,
+		PartyId:        promoCode.PartyId,
+		Code:           promoCode.Code,
+		DiscountType:   promoCode.DiscountType,
+		DiscountValue:  promoCode.DiscountValue,
+		MaxRedemptions: promoCode.MaxRedemptions,
+		Redemptions:    promoCode.Redemptions,
+		ValidFrom:      promoCode.ValidFrom,
+		ValidUntil:     promoCode.ValidUntil,
+		CreatedDate:    promoCode.CreatedDate,
+	}
+}
+
+type PromoCodesDto struct {
+	PromoCodes []PromoCodeDto `json:"promoCodes"`
+}
+
+func (p PromoCodeDto) DomainToDtoCollection(promoCodes []domain.PromoCode) PromoCodesDto {
+	result := make([]PromoCodeDto, len(promoCodes))
+
+	for i := range promoCodes {
+		result[i] = p.DomainToDto(promoCodes[i])
+	}
+
+	return PromoCodesDto{PromoCodes: result}
+}
+
+func (r PromoRedemptionDto) DomainToDto(redemption domain.PromoRedemption) PromoRedemptionDto {
+	return PromoRedemptionDto{
+		Id:          redemption.Id,
+		UserId:      redemption.UserId,
+		Price:       redemption.Price,
+		Discount:    redemption.Discount,
+		CreatedDate: redemption.CreatedDate,
+	}
+}
+
+type PromoRedemptionsDto struct {
+	Redemptions []PromoRedemptionDto `json:"redemptions"`
+}
+
+func (r PromoRedemptionDto) DomainToDtoCollection(redemptions []domain.PromoRedemption) PromoRedemptionsDto {
+	result := make([]PromoRedemptionDto, len(redemptions))
+
+	for i := range redemptions {
+		result[i] = r.DomainToDto(redemptions[i])
+	}
+
+	return PromoRedemptionsDto{Redemptions: result}
+}
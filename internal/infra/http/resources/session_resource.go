@@ -1,16 +1,82 @@
 package resources
 
-import "go-rest-api/internal/domain"
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
 
 type SessionDto struct {
-	Token string  `json:"token"`
-	User  UserDto `json:"user"`
+	Token             string     `json:"token,omitempty"`
+	RefreshToken      string     `json:"refreshToken,omitempty"`
+	ExpiresAt         *time.Time `json:"expiresAt,omitempty"`
+	TwoFactorRequired bool       `json:"twoFactorRequired"`
+	ChallengeToken    string     `json:"challengeToken,omitempty"`
+	User              UserDto    `json:"user"`
 }
 
-func (s SessionDto) DomainToDto(token string, user domain.User) SessionDto {
+func (s SessionDto) DomainToDto(tokens domain.AuthTokens, user domain.User) SessionDto {
 	u := UserDto{}
+	if tokens.TwoFactorRequired() {
+		return SessionDto{
+			TwoFactorRequired: true,
+			ChallengeToken:    tokens.ChallengeToken,
+			User:              u.DomainToDto(user),
+		}
+	}
 	return SessionDto{
-		Token: token,
-		User:  u.DomainToDto(user),
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    &tokens.AccessExpiresAt,
+		User:         u.DomainToDto(user),
+	}
+}
+
+type TotpEnrollmentDto struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+func (t TotpEnrollmentDto) DomainToDto(enrollment domain.TotpEnrollment) TotpEnrollmentDto {
+	return TotpEnrollmentDto{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.Uri,
+	}
+}
+
+type RecoveryCodesDto struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type SessionInfoDto struct {
+	Id           string    `json:"id"`
+	UserAgent    string    `json:"userAgent"`
+	Ip           string    `json:"ip"`
+	CreatedDate  time.Time `json:"createdDate"`
+	LastSeenDate time.Time `json:"lastSeenDate"`
+	Current      bool      `json:"current"`
+}
+
+type SessionsInfoDto struct {
+	Sessions []SessionInfoDto `json:"sessions"`
+}
+
+func (s SessionInfoDto) DomainToDto(sess domain.Session, current domain.Session) SessionInfoDto {
+	return SessionInfoDto{
+		Id:           sess.UUID.String(),
+		UserAgent:    sess.UserAgent,
+		Ip:           sess.Ip,
+		CreatedDate:  sess.CreatedDate,
+		LastSeenDate: sess.LastSeenDate,
+		Current:      sess.UUID == current.UUID,
 	}
 }
+
+func (s SessionInfoDto) DomainToDtoCollection(sessions []domain.Session, current domain.Session) SessionsInfoDto {
+	result := make([]SessionInfoDto, len(sessions))
+
+	for i := range sessions {
+		result[i] = s.DomainToDto(sessions[i], current)
+	}
+
+	return SessionsInfoDto{Sessions: result}
+}
@@ -0,0 +1,31 @@
+package resources
+
+import "go-rest-api/internal/domain"
+
+type TicketTierDto struct {
+	Id        uint64 `json:"id"`
+	Name      string `json:"name"`
+	Price     int32  `json:"price"`
+	Capacity  int32  `json:"capacity"`
+	Remaining int32  `json:"remaining"`
+}
+
+func (t TicketTierDto) DomainToDto(tier domain.TicketTier) TicketTierDto {
+	return TicketTierDto{
+		Id:        tier.Id,
+		Name:      tier.Name,
+		Price:     tier.Price,
+		Capacity:  tier.Capacity,
+		Remaining: tier.Remaining(),
+	}
+}
+
+func (t TicketTierDto) DomainToDtoCollection(tiers []domain.TicketTier) []TicketTierDto {
+	result := make([]TicketTierDto, len(tiers))
+
+	for i := range tiers {
+		result[i] = t.DomainToDto(tiers[i])
+	}
+
+	return result
+}
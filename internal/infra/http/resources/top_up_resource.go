@@ -0,0 +1,26 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type TopUpDto struct {
+	Id            uint64     `json:"id"`
+	Amount        int32      `json:"amount"`
+	Status        string     `json:"status"`
+	CheckoutUrl   string     `json:"checkoutUrl"`
+	CompletedDate *time.Time `json:"completedDate"`
+	CreatedDate   time.Time  `json:"createdDate"`
+}
+
+func (t TopUpDto) DomainToDto(topUp domain.TopUp) TopUpDto {
+	return TopUpDto{
+		Id:            topUp.Id,
+		Amount:        topUp.Amount,
+		Status:        topUp.Status,
+		CheckoutUrl:   topUp.CheckoutUrl,
+		CompletedDate: topUp.CompletedDate,
+		CreatedDate:   topUp.CreatedDate,
+	}
+}
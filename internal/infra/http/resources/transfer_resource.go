@@ -0,0 +1,30 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type TransferDto struct {
+	Id          uint64    `json:"id"`
+	SenderId    uint64    `json:"senderId"`
+	RecipientId uint64    `json:"recipientId"`
+	PartyId     *uint64   `json:"partyId"`
+	Amount      int32     `json:"amount"`
+	Note        string    `json:"note"`
+	Balance     int32     `json:"balance"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+func (t TransferDto) DomainToDto(transfer domain.Transfer) TransferDto {
+	return TransferDto{
+		Id:          transfer.Id,
+		SenderId:    transfer.SenderId,
+		RecipientId: transfer.RecipientId,
+		PartyId:     transfer.PartyId,
+		Amount:      transfer.Amount,
+		Note:        transfer.Note,
+		Balance:     transfer.Balance,
+		CreatedDate: transfer.CreatedDate,
+	}
+}
@@ -0,0 +1,30 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UploadDto struct {
+	Id           uuid.UUID `json:"id"`
+	Url          string    `json:"url"`
+	MediumUrl    string    `json:"mediumUrl"`
+	ThumbnailUrl string    `json:"thumbnailUrl"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	CreatedDate  time.Time `json:"createdDate"`
+}
+
+func (u UploadDto) DomainToDto(upload domain.Upload) UploadDto {
+	return UploadDto{
+		Id:           upload.Id,
+		Url:          upload.Url,
+		MediumUrl:    upload.MediumUrl,
+		ThumbnailUrl: upload.ThumbnailUrl,
+		ContentType:  upload.ContentType,
+		Size:         upload.Size,
+		CreatedDate:  upload.CreatedDate,
+	}
+}
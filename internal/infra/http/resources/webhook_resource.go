@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"go-rest-api/internal/domain"
+	"time"
+)
+
+type WebhookDto struct {
+	Id          uint64    `json:"id"`
+	Url         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+func (w WebhookDto) DomainToDto(domainWebhook domain.Webhook) WebhookDto {
+	return WebhookDto{
+		Id:          domainWebhook.Id,
+		Url:         domainWebhook.Url,
+		Events:      domainWebhook.Events,
+		CreatedDate: domainWebhook.CreatedDate,
+	}
+}
+
+func (w WebhookDto) DomainToDtoWithSecret(domainWebhook domain.Webhook) WebhookDto {
+	dto := w.DomainToDto(domainWebhook)
+	dto.Secret = domainWebhook.Secret
+	return dto
+}
+
+type WebhooksDto struct {
+	Webhooks []WebhookDto `json:"webhooks"`
+}
+
+func (w WebhookDto) DomainToDtoCollection(domainWebhooks []domain.Webhook) WebhooksDto {
+	result := make([]WebhookDto, len(domainWebhooks))
+
+	for i := range domainWebhooks {
+		result[i] = w.DomainToDto(domainWebhooks[i])
+	}
+
+	return WebhooksDto{Webhooks: result}
+}
+
+type WebhookDeliveryDto struct {
+	Id          uint64    `json:"id"`
+	Event       string    `json:"event"`
+	Payload     string    `json:"payload"`
+	Attempt     int32     `json:"attempt"`
+	StatusCode  int32     `json:"statusCode"`
+	Success     bool      `json:"success"`
+	Error       string    `json:"error"`
+	CreatedDate time.Time `json:"createdDate"`
+}
+
+type WebhookDeliveriesDto struct {
+	Deliveries  []WebhookDeliveryDto `json:"items"`
+	Total       uint64               `json:"total"`
+	CurrentPage int32                `json:"currentPage"`
+	LastPage    int32                `json:"lastPage"`
+}
+
+func (d WebhookDeliveryDto) DomainToDto(domainDelivery domain.WebhookDelivery) WebhookDeliveryDto {
+	return WebhookDeliveryDto{
+		Id:          domainDelivery.Id,
+		Event:       domainDelivery.Event,
+		Payload:     domainDelivery.Payload,
+		Attempt:     domainDelivery.Attempt,
+		StatusCode:  domainDelivery.StatusCode,
+		Success:     domainDelivery.Success,
+		Error:       domainDelivery.Error,
+		CreatedDate: domainDelivery.CreatedDate,
+	}
+}
+
+func (d WebhookDeliveryDto) DomainToDtoCollection(domainDeliveries domain.WebhookDeliveries) WebhookDeliveriesDto {
+	result := make([]WebhookDeliveryDto, len(domainDeliveries.Deliveries))
+
+	for i := range domainDeliveries.Deliveries {
+		result[i] = d.DomainToDto(domainDeliveries.Deliveries[i])
+	}
+
+	return WebhookDeliveriesDto{
+		Deliveries:  result,
+		Total:       domainDeliveries.Total,
+		CurrentPage: domainDeliveries.CurrentPage,
+		LastPage:    domainDeliveries.LastPage,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"go-rest-api/config/container"
 	"go-rest-api/internal/domain"
+	"go-rest-api/internal/infra/filesystem"
 	"go-rest-api/internal/infra/http/controllers"
 	"go-rest-api/internal/infra/http/middlewares"
 	"net/http"
@@ -19,7 +20,7 @@ import (
 func CreateRouter(con container.Container) http.Handler {
 	router := chi.NewRouter()
 
-	router.Use(middleware.RedirectSlashes, middleware.Logger, cors.Handler(cors.Options{
+	router.Use(middleware.RedirectSlashes, con.RealIpMw, middleware.Logger, cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
@@ -36,7 +37,7 @@ func CreateRouter(con container.Container) http.Handler {
 		apiRouter.Route("/v1", func(apiRouter chi.Router) {
 			apiRouter.Group(func(apiRouter chi.Router) {
 				apiRouter.Route("/auth", func(apiRouter chi.Router) {
-					AuthRouter(apiRouter, con.SessionController, con.AuthMw)
+					AuthRouter(apiRouter, con.SessionController, con.OidcController, con.AuthMw)
 				})
 				apiRouter.Route("/user", func(apiRouter chi.Router) {
 					apiRouter.Use(con.AuthMw)
@@ -50,13 +51,30 @@ func CreateRouter(con container.Container) http.Handler {
 					apiRouter.Use(con.AuthMw)
 					PartyActionsRouter(apiRouter, con)
 				})
+				apiRouter.Route("/payments", func(apiRouter chi.Router) {
+					PaymentRouter(apiRouter, con.TopUpController)
+				})
+				apiRouter.Route("/calendar", func(apiRouter chi.Router) {
+					CalendarRouter(apiRouter, con.CalendarController)
+				})
+				apiRouter.Route("/feeds", func(apiRouter chi.Router) {
+					FeedRouter(apiRouter, con.FeedController)
+				})
+				apiRouter.Route("/admin", func(apiRouter chi.Router) {
+					apiRouter.Use(con.AuthMw)
+					apiRouter.Use(middlewares.RequireSession)
+					apiRouter.Use(middlewares.RequireRole(domain.RoleModerator))
+					AdminRouter(apiRouter, con)
+				})
 			})
 		})
 	})
 
+	router.Get("/.well-known/jwks.json", con.JwksController.Jwks())
+
 	router.Get("/static/*", func(w http.ResponseWriter, r *http.Request) {
 		workDir, _ := os.Getwd()
-		filesDir := http.Dir(filepath.Join(workDir, "file_storage"))
+		filesDir := filesOnlyFileSystem{http.Dir(filepath.Join(workDir, filesystem.LocalStorageDir))}
 		requestCtx := chi.RouteContext(r.Context())
 		pathPrefix := strings.TrimSuffix(requestCtx.RoutePattern(), "/*")
 		fs := http.StripPrefix(pathPrefix, http.FileServer(filesDir))
@@ -66,7 +84,7 @@ func CreateRouter(con container.Container) http.Handler {
 	return router
 }
 
-func AuthRouter(r chi.Router, sc controllers.SessionController, amw func(http.Handler) http.Handler) {
+func AuthRouter(r chi.Router, sc controllers.SessionController, oc controllers.OidcController, amw func(http.Handler) http.Handler) {
 	r.Route("/", func(apiRouter chi.Router) {
 		apiRouter.Post(
 			"/register",
@@ -76,102 +94,416 @@ func AuthRouter(r chi.Router, sc controllers.SessionController, amw func(http.Ha
 			"/login",
 			sc.Login(),
 		)
-		apiRouter.With(amw).Delete(
+		apiRouter.Post(
+			"/refresh",
+			sc.Refresh(),
+		)
+		apiRouter.Post(
+			"/2fa/verify",
+			sc.VerifyTwoFactor(),
+		)
+		apiRouter.Get(
+			"/oidc/{provider}",
+			oc.Login(),
+		)
+		apiRouter.Get(
+			"/oidc/{provider}/callback",
+			oc.Callback(),
+		)
+		apiRouter.Post(
+			"/oidc/exchange",
+			oc.Exchange(),
+		)
+		apiRouter.With(amw).With(middlewares.RequireSession).Delete(
 			"/logout",
 			sc.Logout(),
 		)
+		apiRouter.Post(
+			"/password/forgot",
+			sc.ForgotPassword(),
+		)
+		apiRouter.Post(
+			"/password/reset",
+			sc.ResetPassword(),
+		)
+		apiRouter.Post(
+			"/email/verify",
+			sc.VerifyEmail(),
+		)
+		apiRouter.With(amw).With(middlewares.RequireSession).Post(
+			"/email/resend",
+			sc.ResendVerificationEmail(),
+		)
+		apiRouter.Post(
+			"/email/change/confirm",
+			sc.ConfirmEmailChange(),
+		)
 	})
 }
 
 func UserRouter(r chi.Router, con container.Container) {
+	webhookObjMw := middlewares.PathObjectMiddleware(con.WebhookService)
+	isWebhookOwnerMw := middlewares.IsOwnerMiddleware[domain.Webhook]()
+	tokenObjMw := middlewares.PathObjectMiddleware(con.PersonalAccessTokenService)
+	isTokenOwnerMw := middlewares.IsOwnerMiddleware[domain.PersonalAccessToken]()
+	topUpObjMw := middlewares.PathObjectMiddleware(con.TopUpService)
+	isTopUpOwnerMw := middlewares.IsOwnerMiddleware[domain.TopUp]()
+	usersReadMw := middlewares.RequireScope(domain.ScopeUsersRead)
+	usersWriteMw := middlewares.RequireScope(domain.ScopeUsersWrite)
+	webhooksReadMw := middlewares.RequireScope(domain.ScopeWebhooksRead)
+	webhooksWriteMw := middlewares.RequireScope(domain.ScopeWebhooksWrite)
 	r.Route("/", func(apiRouter chi.Router) {
-		apiRouter.Get(
+		apiRouter.With(usersReadMw).Get(
 			"/me",
 			con.FindMe(),
 		)
-		apiRouter.Get(
+		apiRouter.With(usersReadMw).Get(
 			"/{userId}",
 			con.FindUserById(),
 		)
-		apiRouter.Post(
-			"/me/balance",
-			con.UpdateMyBalance(),
-		)
-		apiRouter.Get(
+		apiRouter.With(usersReadMw).Get(
 			"/me/favorite/users",
 			con.GetFavorites(),
 		)
-		apiRouter.Get(
+		apiRouter.With(usersReadMw).Get(
 			"/me/favorite/users/parties",
 			con.GetPartiesByFavoriteUsers(),
 		)
-		apiRouter.Get(
+		apiRouter.With(usersReadMw).Get(
 			"/me/favorite/check/{likedId}",
 			con.LikeExists(),
 		)
-		apiRouter.Get(
+		apiRouter.With(usersReadMw).Get(
 			"/favorite/{likedId}",
 			con.GetByLikedUser(),
 		)
-		apiRouter.Get(
+		apiRouter.With(usersWriteMw).Get(
 			"/me/favorite/add/{likedId}",
 			con.SetLike(),
 		)
-		apiRouter.Delete(
+		apiRouter.With(usersWriteMw).Delete(
 			"/me/favorite/remove/{likedId}",
 			con.DeleteLike(),
 		)
+		apiRouter.With(webhooksReadMw).Get(
+			"/me/webhooks",
+			con.WebhookController.FindMy(),
+		)
+		apiRouter.With(webhooksWriteMw).Post(
+			"/me/webhooks",
+			con.WebhookController.Save(),
+		)
+		apiRouter.With(webhooksReadMw).With(webhookObjMw).With(isWebhookOwnerMw).Get(
+			"/me/webhooks/{webhookId}/deliveries",
+			con.WebhookController.FindDeliveries(),
+		)
+		apiRouter.With(webhooksWriteMw).With(webhookObjMw).With(isWebhookOwnerMw).Delete(
+			"/me/webhooks/{webhookId}",
+			con.WebhookController.Delete(),
+		)
+		apiRouter.Group(func(apiRouter chi.Router) {
+			apiRouter.Use(middlewares.RequireSession)
+			apiRouter.Post(
+				"/me/top-ups",
+				con.TopUpController.Save(),
+			)
+			apiRouter.With(topUpObjMw).With(isTopUpOwnerMw).Get(
+				"/me/top-ups/{topUpId}",
+				con.TopUpController.FindById(),
+			)
+			apiRouter.Post(
+				"/me/transfers",
+				con.TransferController.Save(),
+			)
+			apiRouter.Post(
+				"/me/calendar",
+				con.CalendarController.SaveFeed(),
+			)
+			apiRouter.Delete(
+				"/me/calendar",
+				con.CalendarController.DeleteFeed(),
+			)
+			apiRouter.Put(
+				"/me/password",
+				con.SessionController.ChangePassword(),
+			)
+			apiRouter.Put(
+				"/me/email",
+				con.SessionController.ChangeEmail(),
+			)
+			apiRouter.Post(
+				"/me/2fa/enroll",
+				con.TwoFactorController.Enroll(),
+			)
+			apiRouter.Post(
+				"/me/2fa/confirm",
+				con.TwoFactorController.Confirm(),
+			)
+			apiRouter.Delete(
+				"/me/2fa",
+				con.TwoFactorController.Disable(),
+			)
+			apiRouter.Get(
+				"/me/sessions",
+				con.FindMySessions(),
+			)
+			apiRouter.Delete(
+				"/me/sessions",
+				con.SessionController.RevokeOtherSessions(),
+			)
+			apiRouter.Delete(
+				"/me/sessions/{sessionId}",
+				con.SessionController.RevokeSession(),
+			)
+			apiRouter.Get(
+				"/me/tokens",
+				con.PersonalAccessTokenController.FindMy(),
+			)
+			apiRouter.Post(
+				"/me/tokens",
+				con.PersonalAccessTokenController.Save(),
+			)
+			apiRouter.With(tokenObjMw).With(isTokenOwnerMw).Delete(
+				"/me/tokens/{tokenId}",
+				con.PersonalAccessTokenController.Delete(),
+			)
+		})
+	})
+}
+
+func PaymentRouter(r chi.Router, tc controllers.TopUpController) {
+	r.Route("/", func(apiRouter chi.Router) {
+		apiRouter.Post(
+			"/webhook",
+			tc.Webhook(),
+		)
+		// Only set up when APP_ENV=development, see payments.NewProvider.
+		if tc.HasFakeCheckout() {
+			apiRouter.Get(
+				"/fake/checkout/{sessionId}",
+				tc.FakeCheckout(),
+			)
+		}
+	})
+}
+
+func CalendarRouter(r chi.Router, cc controllers.CalendarController) {
+	r.Route("/", func(apiRouter chi.Router) {
+		apiRouter.Get(
+			"/{token}.ics",
+			cc.Feed(),
+		)
+	})
+}
+
+func FeedRouter(r chi.Router, fc controllers.FeedController) {
+	r.Route("/", func(apiRouter chi.Router) {
+		apiRouter.Get(
+			"/parties.{format:atom|rss}",
+			fc.Parties(),
+		)
+		apiRouter.Get(
+			"/creator/{creatorId}.{format:atom|rss}",
+			fc.Creator(),
+		)
+	})
+}
+
+func AdminRouter(r chi.Router, con container.Container) {
+	userObjMw := middlewares.PathObjectMiddleware(con.AdminService)
+	partyObjMw := middlewares.PathObjectMiddleware(con.PartyService)
+	adminOnlyMw := middlewares.RequireRole(domain.RoleAdmin)
+	r.Route("/", func(apiRouter chi.Router) {
+		apiRouter.Get(
+			"/users",
+			con.AdminController.FindUsers(),
+		)
+		apiRouter.With(userObjMw).Get(
+			"/users/{userId}",
+			con.AdminController.FindUser(),
+		)
+		apiRouter.With(userObjMw).Post(
+			"/users/{userId}/ban",
+			con.AdminController.Ban(),
+		)
+		apiRouter.With(userObjMw).Delete(
+			"/users/{userId}/ban",
+			con.AdminController.Unban(),
+		)
+		apiRouter.With(adminOnlyMw).With(userObjMw).Put(
+			"/users/{userId}/role",
+			con.AdminController.ChangeRole(),
+		)
+		apiRouter.With(adminOnlyMw).With(userObjMw).Post(
+			"/users/{userId}/balance",
+			con.AdminController.AdjustBalance(),
+		)
+		apiRouter.With(partyObjMw).Delete(
+			"/parties/{partyId}",
+			con.PartyController.Delete(),
+		)
 	})
 }
 
 func PartyRouter(r chi.Router, con container.Container) {
 	pathObjMw := middlewares.PathObjectMiddleware(con.PartyService)
 	isOwnerMw := middlewares.IsOwnerMiddleware[domain.Party]()
+	seriesObjMw := middlewares.PathObjectMiddleware(con.PartySeriesService)
+	isSeriesOwnerMw := middlewares.IsOwnerMiddleware[domain.PartySeries]()
+	partiesReadMw := middlewares.RequireScope(domain.ScopePartiesRead)
+	partiesWriteMw := middlewares.RequireScope(domain.ScopePartiesWrite)
 	r.Route("/", func(apiRouter chi.Router) {
-		apiRouter.Get(
+		apiRouter.With(partiesReadMw).Get(
 			"/parties",
 			con.PartyController.GetParties(),
 		)
-		apiRouter.Get(
+		apiRouter.With(partiesReadMw).Get(
 			"/parties/creator/{creatorId}",
 			con.PartyController.FindByCreatorId(),
 		)
-		apiRouter.Get(
+		apiRouter.With(partiesReadMw).Get(
 			"/party/{partyId}",
 			con.PartyController.FindById(),
 		)
-		apiRouter.Post(
+		apiRouter.With(partiesReadMw).With(pathObjMw).Get(
+			"/party/{partyId}.ics",
+			con.CalendarController.PartyEvent(),
+		)
+		apiRouter.With(partiesWriteMw).Post(
+			"/uploads",
+			con.UploadController.Save(),
+		)
+		apiRouter.With(partiesWriteMw).Post(
 			"/party",
 			con.PartyController.Save(),
 		)
-		apiRouter.With(pathObjMw).With(isOwnerMw).Put(
+		apiRouter.With(partiesReadMw).With(seriesObjMw).Get(
+			"/party/series/{seriesId}",
+			con.PartySeriesController.FindById(),
+		)
+		apiRouter.With(partiesWriteMw).With(seriesObjMw).With(isSeriesOwnerMw).Delete(
+			"/party/series/{seriesId}",
+			con.PartySeriesController.Cancel(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Put(
 			"/party/{partyId}",
 			con.PartyController.Update(),
 		)
-		apiRouter.With(pathObjMw).With(isOwnerMw).Delete(
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Delete(
 			"/party/{partyId}",
 			con.PartyController.Delete(),
 		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Post(
+			"/party/{partyId}/tiers",
+			con.TicketTierController.Save(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Put(
+			"/party/{partyId}/tiers/{tierId}",
+			con.TicketTierController.Update(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Delete(
+			"/party/{partyId}/tiers/{tierId}",
+			con.TicketTierController.Delete(),
+		)
+		apiRouter.With(partiesReadMw).With(pathObjMw).With(isOwnerMw).Get(
+			"/party/{partyId}/promo-codes",
+			con.PromoCodeController.FindByParty(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Post(
+			"/party/{partyId}/promo-codes",
+			con.PromoCodeController.Save(),
+		)
+		apiRouter.With(partiesReadMw).With(pathObjMw).With(isOwnerMw).Get(
+			"/party/{partyId}/promo-codes/{promoCodeId}/redemptions",
+			con.PromoCodeController.FindRedemptions(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Delete(
+			"/party/{partyId}/promo-codes/{promoCodeId}",
+			con.PromoCodeController.Delete(),
+		)
+		apiRouter.With(middlewares.RequireSession).With(pathObjMw).Post(
+			"/party/{partyId}/tips",
+			con.TransferController.Tip(),
+		)
+		apiRouter.With(partiesReadMw).With(pathObjMw).Get(
+			"/party/{partyId}/images",
+			con.PartyImageController.FindImages(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Post(
+			"/party/{partyId}/images",
+			con.PartyImageController.SaveImage(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Put(
+			"/party/{partyId}/images/order",
+			con.PartyImageController.Reorder(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Put(
+			"/party/{partyId}/images/{imageId}/cover",
+			con.PartyImageController.SetCover(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Delete(
+			"/party/{partyId}/images/{imageId}",
+			con.PartyImageController.DeleteImage(),
+		)
+		apiRouter.With(partiesReadMw).With(pathObjMw).Get(
+			"/party/{partyId}/gallery",
+			con.PartyImageController.FindGallery(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).Post(
+			"/party/{partyId}/gallery",
+			con.PartyImageController.SavePhoto(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).With(isOwnerMw).Put(
+			"/party/{partyId}/gallery/{imageId}/approve",
+			con.PartyImageController.ApprovePhoto(),
+		)
+		apiRouter.With(partiesWriteMw).With(pathObjMw).Delete(
+			"/party/{partyId}/gallery/{imageId}",
+			con.PartyImageController.DeletePhoto(),
+		)
 	})
 }
 
 func PartyActionsRouter(r chi.Router, con container.Container) {
+	partiesReadMw := middlewares.RequireScope(domain.ScopePartiesRead)
+	partiesWriteMw := middlewares.RequireScope(domain.ScopePartiesWrite)
 	r.Route("/", func(apiRouter chi.Router) {
-		apiRouter.Get(
+		apiRouter.With(partiesWriteMw).Post(
 			"/party/join/{partyId}",
 			con.MemberController.Save(),
 		)
-		apiRouter.Get(
+		apiRouter.With(partiesReadMw).Get(
 			"/party/check/{partyId}",
 			con.MemberController.Exists(),
 		)
-		apiRouter.Delete(
+		apiRouter.With(partiesWriteMw).Delete(
 			"/party/leave/{partyId}",
 			con.MemberController.Delete(),
 		)
 	})
 }
 
+type filesOnlyFileSystem struct {
+	http.FileSystem
+}
+
+func (fs filesOnlyFileSystem) Open(name string) (http.File, error) {
+	file, err := fs.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if stat.IsDir() {
+		_ = file.Close()
+		return nil, os.ErrNotExist
+	}
+	return file, nil
+}
+
 func notFoundJson() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		controllers.NotFound(w, errors.New("resource Not Found"))
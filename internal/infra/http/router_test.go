@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticFilesDoNotListDirectories(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "parties"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "parties", "photo.jpg"), []byte("photo"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.FileServer(filesOnlyFileSystem{http.Dir(dir)})
+
+	for path, status := range map[string]int{
+		"/":                  http.StatusNotFound,
+		"/parties/":          http.StatusNotFound,
+		"/parties":           http.StatusNotFound,
+		"/parties/photo.jpg": http.StatusOK,
+	} {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != status {
+			t.Errorf("GET %s = %d, want %d", path, w.Code, status)
+		}
+	}
+}
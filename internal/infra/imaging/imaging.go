@@ -0,0 +1,152 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/gabriel-vasile/mimetype"
+	"golang.org/x/image/webp"
+)
+
+const (
+	MediumSize    = 1024
+	ThumbnailSize = 256
+
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedImage = errors.New("only JPEG, PNG and WebP images are supported")
+	ErrInvalidImage     = errors.New("the image could not be read")
+	ErrImageDimensions  = errors.New("the image is too large")
+)
+
+type Processed struct {
+	ContentType      string
+	Extension        string
+	VariantExtension string
+	Original         []byte
+	Medium           []byte
+	Thumbnail        []byte
+}
+
+func Process(data []byte, maxDimension int) (Processed, error) {
+	contentType := mimetype.Detect(data).String()
+	switch contentType {
+	case "image/jpeg", "image/png":
+		return processRaster(data, contentType, maxDimension)
+	case "image/webp":
+		return processWebp(data, maxDimension)
+	default:
+		return Processed{}, ErrUnsupportedImage
+	}
+}
+
+func processRaster(data []byte, contentType string, maxDimension int) (Processed, error) {
+	// Checking the header first keeps huge images from being decoded at all.
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Processed{}, ErrInvalidImage
+	}
+	err = checkDimensions(config.Width, config.Height, maxDimension)
+	if err != nil {
+		return Processed{}, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Processed{}, ErrInvalidImage
+	}
+
+	encode := encodePng
+	extension := ".png"
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(data))
+		encode = encodeJpeg
+		extension = ".jpg"
+	}
+
+	processed := Processed{ContentType: contentType, Extension: extension, VariantExtension: extension}
+	processed.Original, err = encode(img)
+	if err != nil {
+		return Processed{}, err
+	}
+	processed.Medium, processed.Thumbnail, err = variants(img, encode)
+	if err != nil {
+		return Processed{}, err
+	}
+	return processed, nil
+}
+
+func processWebp(data []byte, maxDimension int) (Processed, error) {
+	width, height, stripped, err := parseWebp(data)
+	if err != nil {
+		return Processed{}, ErrInvalidImage
+	}
+	err = checkDimensions(width, height, maxDimension)
+	if err != nil {
+		return Processed{}, err
+	}
+
+	img, err := webp.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		return Processed{}, ErrInvalidImage
+	}
+
+	encode := encodePng
+	extension := ".png"
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		encode = encodeJpeg
+		extension = ".jpg"
+	}
+
+	processed := Processed{ContentType: "image/webp", Extension: ".webp", VariantExtension: extension, Original: stripped}
+	processed.Medium, processed.Thumbnail, err = variants(img, encode)
+	if err != nil {
+		return Processed{}, err
+	}
+	return processed, nil
+}
+
+func variants(img image.Image, encode func(image.Image) ([]byte, error)) ([]byte, []byte, error) {
+	medium, err := variant(img, MediumSize, encode)
+	if err != nil {
+		return nil, nil, err
+	}
+	thumbnail, err := variant(img, ThumbnailSize, encode)
+	if err != nil {
+		return nil, nil, err
+	}
+	return medium, thumbnail, nil
+}
+
+func variant(img image.Image, size int, encode func(image.Image) ([]byte, error)) ([]byte, error) {
+	resized, ok := fit(img, size)
+	if !ok {
+		return nil, nil
+	}
+	return encode(resized)
+}
+
+func checkDimensions(width, height, maxDimension int) error {
+	if width > maxDimension || height > maxDimension {
+		return fmt.Errorf("%w, the limit is %dx%d pixels", ErrImageDimensions, maxDimension, maxDimension)
+	}
+	return nil
+}
+
+func encodeJpeg(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	return buf.Bytes(), err
+}
+
+func encodePng(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	return buf.Bytes(), err
+}
@@ -0,0 +1,101 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"testing"
+)
+
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	img.Set(0, 0, color.RGBA{R: 0xFF, A: 0xFF})
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func size(t *testing.T, data []byte) (int, int) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("DecodeConfig() error = %v", err)
+	}
+	return config.Width, config.Height
+}
+
+func TestProcessMakesVariants(t *testing.T) {
+	processed, err := Process(pngImage(t, 2048, 1024), 6000)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if width, height := size(t, processed.Medium); width != MediumSize || height != MediumSize/2 {
+		t.Fatalf("medium = %dx%d", width, height)
+	}
+	if width, height := size(t, processed.Thumbnail); width != ThumbnailSize || height != ThumbnailSize/2 {
+		t.Fatalf("thumbnail = %dx%d", width, height)
+	}
+	if processed.VariantExtension != ".png" {
+		t.Fatalf("variant extension = %s, want .png", processed.VariantExtension)
+	}
+}
+
+func TestProcessSkipsVariantsForSmallImages(t *testing.T) {
+	processed, err := Process(pngImage(t, 600, 200), 6000)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if processed.Medium != nil {
+		t.Fatal("medium variant was made for an image smaller than the medium size")
+	}
+	if width, height := size(t, processed.Thumbnail); width != ThumbnailSize || height != 85 {
+		t.Fatalf("thumbnail = %dx%d", width, height)
+	}
+}
+
+func TestProcessMakesWebpVariants(t *testing.T) {
+	tests := []struct {
+		file      string
+		extension string
+		decode    func(data []byte) (image.Image, error)
+	}{
+		{"testdata/opaque.webp", ".jpg", func(data []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(data)) }},
+		{"testdata/alpha.webp", ".png", func(data []byte) (image.Image, error) { return png.Decode(bytes.NewReader(data)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			processed, err := Process(data, 6000)
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if processed.ContentType != "image/webp" || processed.Extension != ".webp" || processed.VariantExtension != tt.extension {
+				t.Fatalf("Process() = %s %s %s", processed.ContentType, processed.Extension, processed.VariantExtension)
+			}
+			if processed.Medium != nil {
+				t.Fatal("medium variant was made for an image smaller than the medium size")
+			}
+			thumbnail, err := tt.decode(processed.Thumbnail)
+			if err != nil {
+				t.Fatalf("thumbnail is not %s: %v", tt.extension, err)
+			}
+			if thumbnail.Bounds().Dx() != ThumbnailSize {
+				t.Fatalf("thumbnail width = %d, want %d", thumbnail.Bounds().Dx(), ThumbnailSize)
+			}
+		})
+	}
+}
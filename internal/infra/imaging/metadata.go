@@ -0,0 +1,124 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var errMalformedWebp = errors.New("malformed WebP")
+
+func jpegOrientation(data []byte) int {
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		// Start of scan, the metadata segments all come before it.
+		if marker == 0xDA {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		end := offset + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+		segment := data[offset+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset = end
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 1
+}
+
+func parseWebp(data []byte) (int, int, []byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, nil, errMalformedWebp
+	}
+
+	var width, height int
+	stripped := append([]byte{}, data[:12]...)
+	for offset := 12; offset < len(data); {
+		if offset+8 > len(data) {
+			return 0, 0, nil, errMalformedWebp
+		}
+		fourCC := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		if offset+8+size > len(data) {
+			return 0, 0, nil, errMalformedWebp
+		}
+		// Chunks are padded to an even size, the last padding byte may be missing.
+		end := min(offset+8+size+size%2, len(data))
+		payload := data[offset+8 : offset+8+size]
+
+		switch fourCC {
+		case "VP8X":
+			if len(payload) < 10 {
+				return 0, 0, nil, errMalformedWebp
+			}
+			width = int(uint32(payload[4])|uint32(payload[5])<<8|uint32(payload[6])<<16) + 1
+			height = int(uint32(payload[7])|uint32(payload[8])<<8|uint32(payload[9])<<16) + 1
+		case "VP8 ":
+			if width == 0 && len(payload) >= 10 {
+				width = int(binary.LittleEndian.Uint16(payload[6:]) & 0x3FFF)
+				height = int(binary.LittleEndian.Uint16(payload[8:]) & 0x3FFF)
+			}
+		case "VP8L":
+			if width == 0 && len(payload) >= 5 && payload[0] == 0x2F {
+				bits := binary.LittleEndian.Uint32(payload[1:])
+				width = int(bits&0x3FFF) + 1
+				height = int(bits>>14&0x3FFF) + 1
+			}
+		}
+
+		if fourCC != "EXIF" && fourCC != "XMP " {
+			chunk := append([]byte{}, data[offset:end]...)
+			if fourCC == "VP8X" {
+				// Clear the flags announcing EXIF (bit 3) and XMP (bit 2) metadata.
+				chunk[8] &^= 0x08 | 0x04
+			}
+			stripped = append(stripped, chunk...)
+		}
+		offset = end
+	}
+
+	if width == 0 || height == 0 {
+		return 0, 0, nil, errMalformedWebp
+	}
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+	return width, height, stripped, nil
+}
@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"fmt"
+	"go-rest-api/config"
+	"log"
+	"net/smtp"
+	"strings"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+type logMailer struct{}
+
+func NewMailer(config config.Configuration) Mailer {
+	if config.SmtpHost == "" {
+		return logMailer{}
+	}
+
+	var auth smtp.Auth
+	if config.SmtpUser != "" {
+		auth = smtp.PlainAuth("", config.SmtpUser, config.SmtpPassword, config.SmtpHost)
+	}
+
+	return smtpMailer{
+		addr: config.SmtpHost + ":" + config.SmtpPort,
+		auth: auth,
+		from: config.MailFrom,
+	}
+}
+
+func (m smtpMailer) Send(to, subject, body string) error {
+	message := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(message))
+	if err != nil {
+		return fmt.Errorf("smtpMailer: failed to send mail: %w", err)
+	}
+	return nil
+}
+
+func (m logMailer) Send(to, subject, body string) error {
+	log.Printf("Mail to %s: %s\n%s", to, subject, body)
+	return nil
+}
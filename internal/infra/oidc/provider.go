@@ -0,0 +1,266 @@
+package oidc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/internal/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrExchangeFailed = errors.New("failed to exchange authorization code")
+
+type Provider struct {
+	cfg         config.OidcProvider
+	redirectUrl string
+	httpClient  *http.Client
+
+	mu        sync.Mutex
+	endpoints *endpoints
+}
+
+type endpoints struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+type emailResponse struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+func NewProvider(cfg config.OidcProvider, apiUrl string) *Provider {
+	return &Provider{
+		cfg:         cfg,
+		redirectUrl: fmt.Sprintf("%s/api/v1/auth/oidc/%s/callback", strings.TrimSuffix(apiUrl, "/"), cfg.Name),
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) AuthCodeUrl(state, codeVerifier string) (string, error) {
+	ep, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	authUrl, err := url.Parse(ep.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	query := authUrl.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientId)
+	query.Set("redirect_uri", p.redirectUrl)
+	query.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	authUrl.RawQuery = query.Encode()
+
+	return authUrl.String(), nil
+}
+
+func (p *Provider) Exchange(code, codeVerifier string) (string, error) {
+	ep, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectUrl)
+	form.Set("client_id", p.cfg.ClientId)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequest(http.MethodPost, ep.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	err = p.do(req, &token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrExchangeFailed, err)
+	}
+	if token.AccessToken == "" {
+		return "", ErrExchangeFailed
+	}
+	return token.AccessToken, nil
+}
+
+func (p *Provider) UserInfo(accessToken string) (domain.OidcProfile, error) {
+	ep, err := p.discover()
+	if err != nil {
+		return domain.OidcProfile{}, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, ep.UserinfoEndpoint, nil)
+	if err != nil {
+		return domain.OidcProfile{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var info map[string]any
+	err = p.do(req, &info)
+	if err != nil {
+		return domain.OidcProfile{}, err
+	}
+
+	profile := domain.OidcProfile{
+		Subject:       claimString(info[p.cfg.SubjectClaim]),
+		Email:         claimString(info["email"]),
+		EmailVerified: info["email_verified"] == true || info["email_verified"] == "true",
+		Name:          claimString(info["name"]),
+	}
+	if profile.Subject == "" {
+		return domain.OidcProfile{}, fmt.Errorf("userinfo response has no %s claim", p.cfg.SubjectClaim)
+	}
+	if profile.Name == "" {
+		profile.Name = claimString(info["login"])
+	}
+
+	if p.cfg.EmailsUrl != "" {
+		profile.Email, profile.EmailVerified, err = p.verifiedEmail(accessToken)
+		if err != nil {
+			return domain.OidcProfile{}, err
+		}
+	}
+	return profile, nil
+}
+
+func (p *Provider) verifiedEmail(accessToken string) (string, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, p.cfg.EmailsUrl, nil)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var emails []emailResponse
+	err = p.do(req, &emails)
+	if err != nil {
+		return "", false, err
+	}
+
+	var verified string
+	for _, email := range emails {
+		if !email.Verified {
+			continue
+		}
+		if email.Primary {
+			return email.Email, true, nil
+		}
+		if verified == "" {
+			verified = email.Email
+		}
+	}
+	return verified, verified != "", nil
+}
+
+func claimString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
+
+func (p *Provider) discover() (endpoints, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoints != nil {
+		return *p.endpoints, nil
+	}
+
+	ep := endpoints{}
+	if p.cfg.Issuer != "" && (p.cfg.AuthUrl == "" || p.cfg.TokenUrl == "" || p.cfg.UserInfoUrl == "") {
+		wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+		req, err := http.NewRequest(http.MethodGet, wellKnown, nil)
+		if err != nil {
+			return endpoints{}, err
+		}
+		err = p.do(req, &ep)
+		if err != nil {
+			return endpoints{}, fmt.Errorf("oidc discovery for %s: %w", p.cfg.Name, err)
+		}
+	}
+	if p.cfg.AuthUrl != "" {
+		ep.AuthorizationEndpoint = p.cfg.AuthUrl
+	}
+	if p.cfg.TokenUrl != "" {
+		ep.TokenEndpoint = p.cfg.TokenUrl
+	}
+	if p.cfg.UserInfoUrl != "" {
+		ep.UserinfoEndpoint = p.cfg.UserInfoUrl
+	}
+	if ep.AuthorizationEndpoint == "" || ep.TokenEndpoint == "" || ep.UserinfoEndpoint == "" {
+		return endpoints{}, fmt.Errorf("oidc provider %s is missing endpoints", p.cfg.Name)
+	}
+
+	p.endpoints = &ep
+	return ep, nil
+}
+
+func (p *Provider) do(req *http.Request, target any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+func GenerateCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
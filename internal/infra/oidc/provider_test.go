@@ -0,0 +1,49 @@
+package oidc
+
+import (
+	"encoding/json"
+	"go-rest-api/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCodeChallenge(t *testing.T) {
+	// Example from RFC 7636, appendix B.
+	challenge := CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if challenge != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Fatalf("CodeChallenge() = %s", challenge)
+	}
+}
+
+func TestUserInfoMapsGithubProfile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 12345678901, "login": "octocat", "name": null, "email": null}`))
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]emailResponse{
+			{Email: "old@example.com", Verified: false},
+			{Email: "octocat@example.com", Primary: true, Verified: true},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := NewProvider(config.OidcProvider{
+		Name:         "github",
+		AuthUrl:      server.URL + "/login/oauth/authorize",
+		TokenUrl:     server.URL + "/login/oauth/access_token",
+		UserInfoUrl:  server.URL + "/user",
+		EmailsUrl:    server.URL + "/user/emails",
+		SubjectClaim: "id",
+	}, "http://localhost:8080")
+
+	profile, err := provider.UserInfo("access")
+	if err != nil {
+		t.Fatalf("UserInfo() error = %v", err)
+	}
+	if profile.Subject != "12345678901" || profile.Email != "octocat@example.com" || !profile.EmailVerified || profile.Name != "octocat" {
+		t.Fatalf("UserInfo() = %+v", profile)
+	}
+}
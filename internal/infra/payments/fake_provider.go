@@ -0,0 +1,77 @@
+package payments
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-rest-api/internal/domain"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const FakeProviderName = "fake"
+
+type FakeProvider struct {
+	apiUrl        string
+	webhookSecret string
+}
+
+type fakeWebhookPayload struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	SessionId string `json:"sessionId"`
+}
+
+func NewFakeProvider(apiUrl, webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		apiUrl:        strings.TrimSuffix(apiUrl, "/"),
+		webhookSecret: webhookSecret,
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakeProvider) CreateCheckout(topUp domain.TopUp) (domain.CheckoutSession, error) {
+	sessionId := "cs_fake_" + uuid.NewString()
+	return domain.CheckoutSession{
+		Id:  sessionId,
+		Url: fmt.Sprintf("%s/api/v1/payments/fake/checkout/%s", p.apiUrl, sessionId),
+	}, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (domain.PaymentEvent, error) {
+	err := verify(p.webhookSecret, payload, signature)
+	if err != nil {
+		return domain.PaymentEvent{}, err
+	}
+
+	var event fakeWebhookPayload
+	err = json.Unmarshal(payload, &event)
+	if err != nil {
+		return domain.PaymentEvent{}, err
+	}
+	return domain.PaymentEvent{
+		Id:        event.Id,
+		Type:      event.Type,
+		SessionId: event.SessionId,
+	}, nil
+}
+
+func (p *FakeProvider) Simulate(sessionId string, paid bool) ([]byte, string, error) {
+	eventType := domain.PaymentEventCheckoutCompleted
+	if !paid {
+		eventType = domain.PaymentEventCheckoutFailed
+	}
+
+	payload, err := json.Marshal(fakeWebhookPayload{
+		Id:        "evt_fake_" + uuid.NewString(),
+		Type:      eventType,
+		SessionId: sessionId,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, sign(p.webhookSecret, payload), nil
+}
@@ -0,0 +1,57 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/internal/domain"
+	"strings"
+)
+
+var (
+	ErrInvalidSignature     = errors.New("invalid payment webhook signature")
+	ErrProviderNotSet       = errors.New("PAYMENT_PROVIDER is not set")
+	ErrMissingWebhookSecret = errors.New("PAYMENT_WEBHOOK_SECRET is required")
+	ErrFakeProviderDisabled = errors.New("the fake payment provider is only available with APP_ENV=development")
+)
+
+type Provider interface {
+	Name() string
+	CreateCheckout(topUp domain.TopUp) (domain.CheckoutSession, error)
+	ParseWebhook(payload []byte, signature string) (domain.PaymentEvent, error)
+}
+
+func NewProvider(cfg config.Configuration) (Provider, error) {
+	if cfg.PaymentProvider == "" {
+		return nil, ErrProviderNotSet
+	}
+	if cfg.PaymentWebhookSecret == "" {
+		return nil, ErrMissingWebhookSecret
+	}
+
+	switch cfg.PaymentProvider {
+	case FakeProviderName:
+		if !cfg.IsDevelopment() {
+			return nil, ErrFakeProviderDisabled
+		}
+		return NewFakeProvider(cfg.ApiUrl, cfg.PaymentWebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.PaymentProvider)
+	}
+}
+
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func verify(secret string, payload []byte, signature string) error {
+	if !strings.HasPrefix(signature, "sha256=") || !hmac.Equal([]byte(sign(secret, payload)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
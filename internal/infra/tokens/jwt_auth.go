@@ -0,0 +1,184 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+type JWTAuth struct {
+	alg        jwa.SignatureAlgorithm
+	signKey    jwk.Key
+	verifyKeys jwk.Set
+	publicKeys jwk.Set
+}
+
+func NewJWTAuth(config config.Configuration) (*JWTAuth, error) {
+	alg := jwa.SignatureAlgorithm(config.JwtAlgorithm)
+
+	ja := &JWTAuth{
+		alg:        alg,
+		verifyKeys: jwk.NewSet(),
+		publicKeys: jwk.NewSet(),
+	}
+
+	entries, err := parseKeyEntries(config.JwtKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	switch alg {
+	case jwa.HS256, jwa.HS384, jwa.HS512:
+		if len(entries) == 0 {
+			log.Printf("JWTAuth: JWT_KEYS is not set, using a random secret, tokens will not survive a restart")
+			secret := make([]byte, 64)
+			_, err = rand.Read(secret)
+			if err != nil {
+				return nil, err
+			}
+			entries = []keyEntry{{kid: "default", value: string(secret)}}
+		}
+		for i, entry := range entries {
+			key, err := jwk.FromRaw([]byte(entry.value))
+			if err != nil {
+				return nil, fmt.Errorf("JWTAuth: invalid secret %q: %w", entry.kid, err)
+			}
+			err = ja.addKey(entry.kid, key, i == 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case jwa.RS256, jwa.RS384, jwa.RS512, jwa.ES256, jwa.ES384, jwa.ES512:
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("JWTAuth: JWT_KEYS must list PEM files for %s", alg)
+		}
+		for i, entry := range entries {
+			pem, err := os.ReadFile(entry.value)
+			if err != nil {
+				return nil, fmt.Errorf("JWTAuth: failed to read key %q: %w", entry.kid, err)
+			}
+			key, err := jwk.ParseKey(pem, jwk.WithPEM(true))
+			if err != nil {
+				return nil, fmt.Errorf("JWTAuth: invalid PEM key %q: %w", entry.kid, err)
+			}
+			err = ja.addKey(entry.kid, key, i == 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("JWTAuth: unsupported algorithm %q", alg)
+	}
+
+	return ja, nil
+}
+
+func (ja *JWTAuth) Encode(claims map[string]interface{}) (jwt.Token, string, error) {
+	token := jwt.New()
+	for name, value := range claims {
+		err := token.Set(name, value)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	signed, err := jwt.Sign(token, jwt.WithKey(ja.alg, ja.signKey))
+	if err != nil {
+		return nil, "", err
+	}
+	return token, string(signed), nil
+}
+
+func (ja *JWTAuth) Decode(tokenString string) (jwt.Token, error) {
+	return jwt.Parse([]byte(tokenString), jwt.WithKeySet(ja.verifyKeys), jwt.WithValidate(true))
+}
+
+func (ja *JWTAuth) PublicKeys() jwk.Set {
+	return ja.publicKeys
+}
+
+func (ja *JWTAuth) addKey(kid string, key jwk.Key, sign bool) error {
+	err := setKeyHeaders(key, kid, ja.alg)
+	if err != nil {
+		return err
+	}
+
+	if key.KeyType() == jwa.OctetSeq {
+		if sign {
+			ja.signKey = key
+		}
+		return ja.verifyKeys.AddKey(key)
+	}
+
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		return fmt.Errorf("JWTAuth: failed to derive public key %q: %w", kid, err)
+	}
+	err = setKeyHeaders(publicKey, kid, ja.alg)
+	if err != nil {
+		return err
+	}
+
+	if sign {
+		if isPublicKey(key) {
+			return fmt.Errorf("JWTAuth: signing key %q must be a private key", kid)
+		}
+		ja.signKey = key
+	}
+
+	err = ja.verifyKeys.AddKey(publicKey)
+	if err != nil {
+		return err
+	}
+	return ja.publicKeys.AddKey(publicKey)
+}
+
+func setKeyHeaders(key jwk.Key, kid string, alg jwa.SignatureAlgorithm) error {
+	err := key.Set(jwk.KeyIDKey, kid)
+	if err != nil {
+		return err
+	}
+	err = key.Set(jwk.AlgorithmKey, alg)
+	if err != nil {
+		return err
+	}
+	return key.Set(jwk.KeyUsageKey, jwk.ForSignature)
+}
+
+func isPublicKey(key jwk.Key) bool {
+	switch key.(type) {
+	case jwk.RSAPublicKey, jwk.ECDSAPublicKey:
+		return true
+	default:
+		return false
+	}
+}
+
+type keyEntry struct {
+	kid   string
+	value string
+}
+
+func parseKeyEntries(raw string) ([]keyEntry, error) {
+	var entries []keyEntry
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		kid, value, found := strings.Cut(item, ":")
+		if !found || kid == "" || value == "" {
+			return nil, errors.New("JWTAuth: JWT_KEYS entries must look like kid:value")
+		}
+		entries = append(entries, keyEntry{kid: kid, value: value})
+	}
+	return entries, nil
+}
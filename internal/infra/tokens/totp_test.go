@@ -0,0 +1,76 @@
+package tokens
+
+import (
+	"testing"
+	"time"
+)
+
+// The SHA1 vectors from RFC 6238 Appendix B, truncated to six digits.
+var rfc6238Secret = totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTotpMatchesRfc6238Vectors(t *testing.T) {
+	tests := []struct {
+		time int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		now := time.Unix(tt.time, 0)
+		code, err := TotpCode(rfc6238Secret, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != tt.code {
+			t.Errorf("TotpCode(%d) = %s, want %s", tt.time, code, tt.code)
+		}
+		step, ok := ValidateTotp(rfc6238Secret, tt.code, now)
+		if !ok || step != tt.time/totpPeriod {
+			t.Errorf("ValidateTotp(%d) = %d, %t, want %d, true", tt.time, step, ok, tt.time/totpPeriod)
+		}
+	}
+}
+
+func TestTotpToleratesOneStepOfDrift(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := TotpCode(rfc6238Secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		drift time.Duration
+		valid bool
+	}{
+		{-2 * totpPeriod * time.Second, false},
+		{-totpPeriod * time.Second, true},
+		{0, true},
+		{totpPeriod * time.Second, true},
+		{2 * totpPeriod * time.Second, false},
+	} {
+		step, ok := ValidateTotp(rfc6238Secret, code, now.Add(tt.drift))
+		if ok != tt.valid {
+			t.Errorf("ValidateTotp() with %s drift = %t, want %t", tt.drift, ok, tt.valid)
+		}
+		if ok && step != now.Unix()/totpPeriod {
+			t.Errorf("ValidateTotp() with %s drift step = %d, want %d", tt.drift, step, now.Unix()/totpPeriod)
+		}
+	}
+}
+
+func TestTotpRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+	for _, code := range []string{"", "28708", "2870820", "abcdef", "287083"} {
+		if _, ok := ValidateTotp(rfc6238Secret, code, now); ok {
+			t.Errorf("ValidateTotp(%q) = true, want false", code)
+		}
+	}
+	if _, ok := ValidateTotp(rfc6238Secret, " 287082 ", now); !ok {
+		t.Error("ValidateTotp() rejected a code with surrounding spaces")
+	}
+}
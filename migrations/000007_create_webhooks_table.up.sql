@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text[] NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_webhook_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id bigserial NOT NULL PRIMARY KEY,
+    webhook_id bigint NOT NULL,
+    event text NOT NULL,
+    payload text NOT NULL,
+    attempt integer NOT NULL,
+    status_code integer NOT NULL DEFAULT 0,
+    success boolean NOT NULL DEFAULT false,
+    error text NOT NULL DEFAULT '',
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_delivery_webhook FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
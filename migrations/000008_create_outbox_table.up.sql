@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id bigserial NOT NULL PRIMARY KEY,
+    event_type text NOT NULL,
+    user_id bigint NOT NULL,
+    payload text NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    processed_date timestamp NULL
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (id) WHERE processed_date IS NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    purpose text NOT NULL,
+    token_hash text NOT NULL UNIQUE,
+    expires_date timestamp NOT NULL,
+    used_date timestamp NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user_token_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE users
+ADD COLUMN verified boolean NOT NULL DEFAULT false;
+
+UPDATE users SET verified = true;
@@ -0,0 +1,2 @@
+ALTER TABLE user_tokens
+ADD COLUMN payload text NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id integer NOT NULL,
+    session_uuid text NOT NULL,
+    token_hash text NOT NULL UNIQUE,
+    expires_date timestamp NOT NULL,
+    used_date timestamp NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_refresh_token_session FOREIGN KEY (user_id, session_uuid) REFERENCES sessions(user_id, uuid) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+ALTER TABLE sessions
+DROP COLUMN created_date,
+DROP COLUMN last_seen_date,
+DROP COLUMN user_agent,
+DROP COLUMN ip;
@@ -0,0 +1,5 @@
+ALTER TABLE sessions
+ADD COLUMN created_date timestamp NOT NULL DEFAULT NOW(),
+ADD COLUMN last_seen_date timestamp NOT NULL DEFAULT NOW(),
+ADD COLUMN user_agent text NOT NULL DEFAULT '',
+ADD COLUMN ip text NOT NULL DEFAULT '';
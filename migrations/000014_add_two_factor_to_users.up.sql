@@ -0,0 +1,12 @@
+ALTER TABLE users
+ADD COLUMN totp_secret text NOT NULL DEFAULT '',
+ADD COLUMN totp_enabled boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    code_hash text NOT NULL,
+    used_date timestamp NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_recovery_code_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
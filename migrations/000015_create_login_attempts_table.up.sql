@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id integer NULL,
+    email text NOT NULL,
+    ip text NOT NULL DEFAULT '',
+    user_agent text NOT NULL DEFAULT '',
+    success boolean NOT NULL DEFAULT false,
+    reason text NOT NULL DEFAULT '',
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_login_attempt_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_email_created_date_idx ON login_attempts (email, created_date);
+CREATE INDEX IF NOT EXISTS login_attempts_ip_created_date_idx ON login_attempts (ip, created_date);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS user_identities (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    provider text NOT NULL,
+    subject text NOT NULL,
+    email text NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user_identity_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT user_identities_provider_subject_key UNIQUE (provider, subject)
+);
+
+CREATE TABLE IF NOT EXISTS oidc_states (
+    state_hash text NOT NULL PRIMARY KEY,
+    provider text NOT NULL,
+    code_verifier text NOT NULL,
+    expires_date timestamp NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW()
+);
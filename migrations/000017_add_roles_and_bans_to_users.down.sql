@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS point_transactions;
+
+ALTER TABLE users
+DROP CONSTRAINT IF EXISTS users_role_check,
+DROP COLUMN IF EXISTS ban_reason,
+DROP COLUMN IF EXISTS banned_date,
+DROP COLUMN IF EXISTS role;
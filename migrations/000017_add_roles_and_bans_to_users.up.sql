@@ -0,0 +1,20 @@
+ALTER TABLE users
+ADD COLUMN role text NOT NULL DEFAULT 'user',
+ADD COLUMN banned_date timestamp NULL,
+ADD COLUMN ban_reason text NOT NULL DEFAULT '',
+ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator', 'admin'));
+
+CREATE TABLE IF NOT EXISTS point_transactions (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    amount integer NOT NULL,
+    balance integer NOT NULL,
+    type text NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    actor_id bigint NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_point_transaction_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_point_transaction_actor FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS point_transactions_user_id_idx ON point_transactions (user_id, created_date);
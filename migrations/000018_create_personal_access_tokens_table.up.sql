@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    name text NOT NULL,
+    token_hash text NOT NULL UNIQUE,
+    prefix text NOT NULL,
+    scopes text[] NOT NULL DEFAULT '{}',
+    expires_date timestamp NULL,
+    last_used_date timestamp NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_personal_access_token_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
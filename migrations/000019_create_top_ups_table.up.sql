@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS top_ups (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    amount integer NOT NULL CHECK (amount > 0),
+    provider text NOT NULL,
+    provider_session_id text NOT NULL,
+    checkout_url text NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    completed_date timestamp NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_top_up_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT top_ups_provider_session_key UNIQUE (provider, provider_session_id)
+);
+
+CREATE TABLE IF NOT EXISTS payment_events (
+    provider text NOT NULL,
+    event_id text NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, event_id)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS transfers;
+
+ALTER TABLE parties
+DROP COLUMN IF EXISTS tips_total;
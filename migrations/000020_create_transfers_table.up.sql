@@ -0,0 +1,18 @@
+ALTER TABLE parties
+ADD COLUMN tips_total integer NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS transfers (
+    id bigserial NOT NULL PRIMARY KEY,
+    sender_id bigint NOT NULL,
+    recipient_id bigint NOT NULL,
+    party_id bigint NULL,
+    amount integer NOT NULL CHECK (amount > 0),
+    note text NOT NULL DEFAULT '',
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_transfer_sender FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_transfer_recipient FOREIGN KEY (recipient_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_transfer_party FOREIGN KEY (party_id) REFERENCES parties(id) ON DELETE SET NULL,
+    CONSTRAINT transfers_sender_recipient_check CHECK (sender_id <> recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS transfers_sender_id_idx ON transfers (sender_id, created_date);
@@ -0,0 +1,5 @@
+ALTER TABLE party_users
+DROP COLUMN IF EXISTS price;
+
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_codes;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id bigserial NOT NULL PRIMARY KEY,
+    party_id bigint NOT NULL,
+    code text NOT NULL,
+    discount_type text NOT NULL,
+    discount_value integer NOT NULL CHECK (discount_value > 0),
+    max_redemptions integer NULL CHECK (max_redemptions > 0),
+    redemptions integer NOT NULL DEFAULT 0,
+    valid_from timestamp NULL,
+    valid_until timestamp NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_promo_code_party FOREIGN KEY (party_id) REFERENCES parties(id) ON DELETE CASCADE,
+    CONSTRAINT promo_codes_party_code_key UNIQUE (party_id, code),
+    CONSTRAINT promo_codes_discount_type_check CHECK (discount_type IN ('percent', 'fixed'))
+);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id bigserial NOT NULL PRIMARY KEY,
+    promo_code_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    price integer NOT NULL,
+    discount integer NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_promo_redemption_code FOREIGN KEY (promo_code_id) REFERENCES promo_codes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_promo_redemption_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT promo_redemptions_code_user_key UNIQUE (promo_code_id, user_id)
+);
+
+ALTER TABLE party_users
+ADD COLUMN price integer NOT NULL DEFAULT 0;
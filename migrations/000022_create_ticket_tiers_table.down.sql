@@ -0,0 +1,5 @@
+ALTER TABLE party_users
+DROP CONSTRAINT IF EXISTS fk_party_user_tier,
+DROP COLUMN IF EXISTS tier_id;
+
+DROP TABLE IF EXISTS ticket_tiers;
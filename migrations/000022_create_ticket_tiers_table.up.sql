@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS ticket_tiers (
+    id bigserial NOT NULL PRIMARY KEY,
+    party_id bigint NOT NULL,
+    name text NOT NULL,
+    price integer NOT NULL CHECK (price >= 0),
+    capacity integer NOT NULL CHECK (capacity > 0),
+    sold integer NOT NULL DEFAULT 0,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_ticket_tier_party FOREIGN KEY (party_id) REFERENCES parties(id) ON DELETE CASCADE,
+    CONSTRAINT ticket_tiers_party_name_key UNIQUE (party_id, name),
+    CONSTRAINT ticket_tiers_sold_check CHECK (sold >= 0 AND sold <= capacity)
+);
+
+ALTER TABLE party_users
+ADD COLUMN tier_id bigint NULL,
+ADD CONSTRAINT fk_party_user_tier FOREIGN KEY (tier_id) REFERENCES ticket_tiers(id) ON DELETE SET NULL;
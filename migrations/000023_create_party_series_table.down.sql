@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS parties_series_id_idx;
+
+ALTER TABLE parties
+DROP CONSTRAINT IF EXISTS fk_party_series,
+DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS party_series;
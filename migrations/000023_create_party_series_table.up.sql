@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS party_series (
+    id bigserial NOT NULL PRIMARY KEY,
+    creator_id bigint NOT NULL,
+    rule text NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_party_series_creator FOREIGN KEY (creator_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE parties
+ADD COLUMN series_id bigint NULL,
+ADD CONSTRAINT fk_party_series FOREIGN KEY (series_id) REFERENCES party_series(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS parties_series_id_idx ON parties (series_id, start_date);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS parties_start_end_date_idx;
+
+ALTER TABLE parties
+DROP CONSTRAINT IF EXISTS parties_end_date_check,
+DROP COLUMN IF EXISTS timezone,
+DROP COLUMN IF EXISTS end_date,
+ALTER COLUMN start_date TYPE timestamp USING start_date AT TIME ZONE 'UTC';
@@ -0,0 +1,12 @@
+ALTER TABLE parties
+ALTER COLUMN start_date TYPE timestamptz USING start_date AT TIME ZONE 'UTC',
+ADD COLUMN end_date timestamptz NULL,
+ADD COLUMN timezone text NOT NULL DEFAULT 'UTC';
+
+UPDATE parties SET end_date = COALESCE(start_date, created_date AT TIME ZONE 'UTC') + INTERVAL '4 hours';
+
+ALTER TABLE parties
+ALTER COLUMN end_date SET NOT NULL,
+ADD CONSTRAINT parties_end_date_check CHECK (end_date > start_date);
+
+CREATE INDEX IF NOT EXISTS parties_start_end_date_idx ON parties (start_date, end_date);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id bigserial NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL UNIQUE,
+    token_hash text NOT NULL UNIQUE,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_calendar_feed_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
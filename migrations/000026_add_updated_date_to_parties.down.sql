@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS parties_creator_id_start_date_idx;
+
+ALTER TABLE parties
+DROP COLUMN IF EXISTS updated_date;
@@ -0,0 +1,10 @@
+ALTER TABLE parties
+ADD COLUMN updated_date timestamp NULL;
+
+UPDATE parties SET updated_date = created_date;
+
+ALTER TABLE parties
+ALTER COLUMN updated_date SET NOT NULL,
+ALTER COLUMN updated_date SET DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS parties_creator_id_start_date_idx ON parties (creator_id, start_date);
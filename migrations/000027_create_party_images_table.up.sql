@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS party_images (
+    id bigserial NOT NULL PRIMARY KEY,
+    party_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    url text NOT NULL,
+    kind text NOT NULL CHECK (kind IN ('host', 'gallery')),
+    status text NOT NULL DEFAULT 'approved' CHECK (status IN ('pending', 'approved')),
+    position integer NOT NULL DEFAULT 0,
+    is_cover boolean NOT NULL DEFAULT false,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_party_image_party FOREIGN KEY (party_id) REFERENCES parties(id) ON DELETE CASCADE,
+    CONSTRAINT fk_party_image_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT party_images_cover_check CHECK (NOT is_cover OR kind = 'host')
+);
+
+CREATE INDEX IF NOT EXISTS party_images_party_id_idx ON party_images (party_id, kind, position);
+CREATE UNIQUE INDEX IF NOT EXISTS party_images_cover_idx ON party_images (party_id) WHERE is_cover;
+
+INSERT INTO party_images (party_id, user_id, url, kind, status, position, is_cover)
+SELECT id, creator_id, image, 'host', 'approved', 0, true FROM parties
+WHERE image IS NOT NULL AND image <> '';
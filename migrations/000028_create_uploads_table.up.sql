@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS uploads (
+    id text NOT NULL PRIMARY KEY,
+    user_id bigint NOT NULL,
+    url text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_upload_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
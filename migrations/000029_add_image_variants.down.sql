@@ -0,0 +1,11 @@
+ALTER TABLE party_images
+DROP COLUMN IF EXISTS medium_url,
+DROP COLUMN IF EXISTS thumbnail_url;
+
+ALTER TABLE parties
+DROP COLUMN IF EXISTS image_medium,
+DROP COLUMN IF EXISTS image_thumbnail;
+
+ALTER TABLE uploads
+DROP COLUMN IF EXISTS medium_url,
+DROP COLUMN IF EXISTS thumbnail_url;
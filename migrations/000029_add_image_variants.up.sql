@@ -0,0 +1,16 @@
+ALTER TABLE uploads
+ADD COLUMN medium_url text NOT NULL DEFAULT '',
+ADD COLUMN thumbnail_url text NOT NULL DEFAULT '';
+
+ALTER TABLE parties
+ADD COLUMN image_medium text NOT NULL DEFAULT '',
+ADD COLUMN image_thumbnail text NOT NULL DEFAULT '';
+
+ALTER TABLE party_images
+ADD COLUMN medium_url text NOT NULL DEFAULT '',
+ADD COLUMN thumbnail_url text NOT NULL DEFAULT '';
+
+-- Images stored before variants existed use the original for every size.
+UPDATE uploads SET medium_url = url, thumbnail_url = url;
+UPDATE parties SET image_medium = image, image_thumbnail = image WHERE image IS NOT NULL;
+UPDATE party_images SET medium_url = url, thumbnail_url = url;
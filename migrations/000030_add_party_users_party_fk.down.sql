@@ -0,0 +1,2 @@
+ALTER TABLE party_users
+DROP CONSTRAINT IF EXISTS fk_party_user_party;
@@ -0,0 +1,4 @@
+DELETE FROM party_users WHERE party_id NOT IN (SELECT id FROM parties);
+
+ALTER TABLE party_users
+ADD CONSTRAINT fk_party_user_party FOREIGN KEY (party_id) REFERENCES parties(id) ON DELETE CASCADE;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS webhook_jobs (
+    id bigserial NOT NULL PRIMARY KEY,
+    webhook_id bigint NOT NULL,
+    event_id bigint NOT NULL,
+    event text NOT NULL,
+    payload text NOT NULL,
+    attempt integer NOT NULL DEFAULT 0,
+    next_attempt_date timestamp NOT NULL DEFAULT NOW(),
+    created_date timestamp NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_webhook_job_webhook FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    CONSTRAINT uq_webhook_job_event UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_jobs_next_attempt_idx ON webhook_jobs (next_attempt_date);